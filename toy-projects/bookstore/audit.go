@@ -0,0 +1,50 @@
+package bookstore
+
+import "time"
+
+// AuditAction is the kind of stock change recorded in a SafeCatalog's audit log.
+type AuditAction string
+
+// These are the stock changes a SafeCatalog records.
+const (
+	AuditAdd     AuditAction = "add"     // A book was added to the catalog, with its copies.
+	AuditRestock AuditAction = "restock" // Copies of a book were put back in stock.
+	AuditReserve AuditAction = "reserve" // Copies of a book were reserved, which takes them out of stock.
+	AuditRelease AuditAction = "release" // A reservation was released, which puts its copies back in stock.
+	AuditBuy     AuditAction = "buy"     // Copies of a book were bought at checkout.
+	AuditRemove  AuditAction = "remove"  // A book was removed from the catalog, with the copies it had in stock.
+)
+
+// AuditEntry records a change of a book's stock in a SafeCatalog.
+type AuditEntry struct {
+	Time   time.Time   // Time is when the change happened, according to the catalog's clock.
+	Action AuditAction // Action is what changed the stock.
+	BookID int         // BookID is the ID of the book whose stock changed.
+	Delta  int         // Delta is the change in the number of copies in stock: positive when copies were added.
+}
+
+// SafeCatalogOption is a configuration function, an optional parameter to NewSafeCatalog.
+type SafeCatalogOption func(*SafeCatalog)
+
+// WithClock replaces the function the catalog uses to timestamp its audit log, time.Now by default.
+// It's mostly useful in tests.
+func WithClock(now func() time.Time) SafeCatalogOption {
+	return func(sc *SafeCatalog) {
+		sc.now = now
+	}
+}
+
+// AuditLog returns a copy of every stock change made through the catalog, oldest first.
+// It helps understand how the stock of a book ended up where it is.
+// The books the catalog was created with aren't in it: only the changes made afterwards are.
+func (sc *SafeCatalog) AuditLog() []AuditEntry {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	return append([]AuditEntry(nil), sc.audit...)
+}
+
+// record appends a stock change to the audit log. The caller must hold the mutex.
+func (sc *SafeCatalog) record(action AuditAction, bookID, delta int) {
+	sc.audit = append(sc.audit, AuditEntry{Time: sc.now(), Action: action, BookID: bookID, Delta: delta})
+}
@@ -6,7 +6,9 @@ package bookstore
 import (
 	"errors"
 	"fmt"
-	// We'll use sort later if we need to order books from the map.
+	"math"
+	"sort" // Used to order books taken from the map.
+	"strings"
 )
 
 // Category represents the genre or subject of a book.
@@ -29,6 +31,7 @@ type Book struct {
 	// Author is the name of the book's author. Exported.
 	Author string
 	// Copies is the number of copies of this book currently in stock. Exported.
+	// Prefer SetCopies, which rejects negative numbers, over assigning it directly.
 	Copies int
 	// ID is a unique identifier for the book. Exported.
 	ID int
@@ -42,10 +45,31 @@ type Book struct {
 	category Category
 	// isFiction is an example of another unexported field.
 	isFiction bool
+	// archived marks a book that was taken out of the catalog's listings, without being deleted.
+	// Use Catalog.Archive and Catalog.Unarchive to change it, and Archived to read it.
+	archived bool
 }
 
 type Catalog map[int]Book
 
+// NewBook creates a book after validating its essential fields.
+// It returns an error if the ID isn't positive or if the title is empty.
+// The new book starts with no copies in stock and no discount.
+func NewBook(id int, title, author string) (*Book, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("non-positive ID %d", id)
+	}
+	if title == "" {
+		return nil, errors.New("empty title")
+	}
+	return &Book{
+		ID:     id,
+		Title:  title,
+		Author: author,
+		// Copies and DiscountPercent are left to their zero value: nothing in stock, no discount.
+	}, nil
+}
+
 var validCategory = map[Category]bool{
 	CategoryAutobiography:     true,
 	CategoryLargePrintRomance: true,
@@ -88,7 +112,31 @@ func (c Catalog) AddBook(book Book) error {
 	return nil
 }
 
-// GetAllBooks retrieves all books from the catalog as a slice.
+// AddBooks adds several books to the catalog, e.g. when importing a supplier's list.
+// Unlike a loop stopping at the first failing AddBook, it tries every book, so that all the conflicts are reported at once:
+// the returned slice holds the error of each book, at the same index, and a nil error for each book that was added.
+// Books are added in order, so if two books of the list share an ID, the first one is added and the second one fails.
+func (c Catalog) AddBooks(books []Book) []error {
+	errs := make([]error, len(books))
+	for i, book := range books {
+		errs[i] = c.AddBook(book)
+	}
+	return errs
+}
+
+// Clone returns a copy of the catalog.
+// Maps are reference types: assigning a catalog to another variable shares the same books.
+// The clone is a new map, and since Book values are copied into it,
+// changing a book of the clone doesn't change the original catalog.
+func (c Catalog) Clone() Catalog {
+	clone := make(Catalog, len(c))
+	for id, b := range c {
+		clone[id] = b
+	}
+	return clone
+}
+
+// GetAllBooks retrieves all books from the catalog as a slice, except the archived ones.
 // It takes a value receiver `Catalog` because it only needs to read from the map, not modify it.
 // Note: Iterating over a map in Go does not guarantee any specific order.
 func (c Catalog) GetAllBooks() []Book {
@@ -96,6 +144,10 @@ func (c Catalog) GetAllBooks() []Book {
 	result := []Book{}
 	// Iterate over the values (books) in the catalog map.
 	for _, b := range c {
+		// Archived books are hidden from the listings.
+		if b.archived {
+			continue
+		}
 		// Append each book to the result slice.
 		result = append(result, b)
 	}
@@ -105,6 +157,179 @@ func (c Catalog) GetAllBooks() []Book {
 	return result
 }
 
+// GetAllBooksIncludingArchived retrieves all books from the catalog as a slice, archived ones included.
+// Like GetAllBooks, it doesn't guarantee any specific order.
+func (c Catalog) GetAllBooksIncludingArchived() []Book {
+	result := make([]Book, 0, len(c))
+	for _, b := range c {
+		result = append(result, b)
+	}
+	return result
+}
+
+// Archive hides a book from the catalog's listings, e.g. a book that is out of print, without deleting it:
+// GetBook still finds it, and its Archived method reports it.
+// It returns an error if the book doesn't exist. Archiving an archived book does nothing.
+func (c Catalog) Archive(id int) error {
+	return c.setArchived(id, true)
+}
+
+// Unarchive brings an archived book back into the catalog's listings.
+// It returns an error if the book doesn't exist. Unarchiving a book that isn't archived does nothing.
+func (c Catalog) Unarchive(id int) error {
+	return c.setArchived(id, false)
+}
+
+// setArchived archives or unarchives a book.
+func (c Catalog) setArchived(id int, archived bool) error {
+	b, err := c.GetBook(id)
+	if err != nil {
+		return err
+	}
+	// The map holds copies of the books: update the copy, then store it back.
+	b.archived = archived
+	c[id] = b
+	return nil
+}
+
+// TopValue returns the n books with the lowest net price: the best deals of the catalog.
+// Books are sorted by ascending net price, and by ID when their net prices are equal.
+// If n is larger than the catalog, all books are returned; if n isn't positive, none are.
+func (c Catalog) TopValue(n int) []Book {
+	if n <= 0 {
+		return []Book{}
+	}
+
+	books := c.GetAllBooks()
+	// GetAllBooks gives no order guarantee, so we sort the books ourselves.
+	sortByNetPrice(books)
+
+	// Clamp n to the number of books in the catalog.
+	n = min(n, len(books))
+	return books[:n]
+}
+
+// CountByCategory returns the number of books of each category in the catalog.
+// Only the categories that have books appear in the map: a missing category means zero books.
+// It counts titles, not copies in stock. Archived books aren't counted, as in GetAllBooks.
+func (c Catalog) CountByCategory() map[Category]int {
+	counts := make(map[Category]int)
+	for category, books := range c.groupByCategory() {
+		counts[category] = len(books)
+	}
+	return counts
+}
+
+// AveragePriceByCategory returns the mean net price, in dollars, of the books of each category in the catalog.
+// Like in CountByCategory, only the categories that have books appear in the map.
+// Each title counts once, whatever its number of copies in stock. Archived books are left out, as in GetAllBooks.
+func (c Catalog) AveragePriceByCategory() map[Category]float64 {
+	averages := make(map[Category]float64)
+	for category, books := range c.groupByCategory() {
+		totalCents := 0
+		for _, b := range books {
+			totalCents += b.NetPriceCents()
+		}
+		// A category is only in the grouping if it has books: there's no division by zero.
+		averages[category] = float64(totalCents) / float64(len(books)) / 100
+	}
+	return averages
+}
+
+// groupByCategory sorts the books of the catalog, except the archived ones, by category.
+// Only the categories that have books are keys of the map.
+func (c Catalog) groupByCategory() map[Category][]Book {
+	groups := make(map[Category][]Book)
+	for _, b := range c.GetAllBooks() {
+		groups[b.Category()] = append(groups[b.Category()], b)
+	}
+	return groups
+}
+
+// DuplicateTitles finds the titles used by more than one book, e.g. a book entered twice by mistake.
+// Titles are matched case-insensitively: the map's keys are the lowercased titles,
+// and its values are the IDs of the books sharing each title, in ascending order.
+// Titles used by a single book don't appear in the map.
+// Unlike the listings, it checks archived books too: it's a data-entry check, and a book archived
+// when it went out of print is still a duplicate if it's entered again.
+func (c Catalog) DuplicateTitles() map[string][]int {
+	idsByTitle := make(map[string][]int)
+	for id, b := range c {
+		title := strings.ToLower(b.Title)
+		idsByTitle[title] = append(idsByTitle[title], id)
+	}
+
+	duplicates := make(map[string][]int)
+	for title, ids := range idsByTitle {
+		if len(ids) < 2 {
+			continue
+		}
+		// Map iteration order is random: sort the IDs to get a stable result.
+		sort.Ints(ids)
+		duplicates[title] = ids
+	}
+	return duplicates
+}
+
+// ByAuthor returns the books written by author, e.g. to suggest "more from this author".
+// Authors are matched case-insensitively, ignoring leading and trailing spaces.
+// Books are sorted by title, and by ID when their titles are equal. Archived books aren't listed, as in GetAllBooks.
+// An empty author matches no books: it returns an empty slice.
+func (c Catalog) ByAuthor(author string) []Book {
+	books := []Book{}
+	author = strings.TrimSpace(author)
+	if author == "" {
+		return books
+	}
+
+	for _, b := range c.GetAllBooks() {
+		// EqualFold compares strings case-insensitively, without building lowercased copies.
+		if strings.EqualFold(strings.TrimSpace(b.Author), author) {
+			books = append(books, b)
+		}
+	}
+
+	sort.Slice(books, func(i, j int) bool {
+		if books[i].Title != books[j].Title {
+			return books[i].Title < books[j].Title
+		}
+		return books[i].ID < books[j].ID
+	})
+	return books
+}
+
+// InPriceRange returns the books whose net price is between minCents and maxCents, both included.
+// Books are sorted by ascending net price, and by ID when their net prices are equal.
+// Archived books aren't listed, as in GetAllBooks: they can't be bought.
+// It returns an error if a bound is negative, or if minCents is greater than maxCents.
+func (c Catalog) InPriceRange(minCents, maxCents int) ([]Book, error) {
+	if minCents < 0 || maxCents < 0 {
+		return nil, fmt.Errorf("negative price range %d-%d", minCents, maxCents)
+	}
+	if minCents > maxCents {
+		return nil, fmt.Errorf("minimum price %d is greater than maximum price %d", minCents, maxCents)
+	}
+
+	books := []Book{}
+	for _, b := range c.GetAllBooks() {
+		if price := b.NetPriceCents(); price >= minCents && price <= maxCents {
+			books = append(books, b)
+		}
+	}
+	sortByNetPrice(books)
+	return books, nil
+}
+
+// sortByNetPrice sorts books by ascending net price, and by ID when their net prices are equal.
+func sortByNetPrice(books []Book) {
+	sort.Slice(books, func(i, j int) bool {
+		if books[i].NetPriceCents() != books[j].NetPriceCents() {
+			return books[i].NetPriceCents() < books[j].NetPriceCents()
+		}
+		return books[i].ID < books[j].ID
+	})
+}
+
 // GetBook retrieves a single book from the catalog by its ID.
 // It takes a value receiver `Catalog` as it only reads from the map.
 // It returns the found Book and nil, or an empty Book and an error if the ID is not found.
@@ -138,6 +363,84 @@ func (b *Book) SetPriceCents(price int) error {
 	return nil
 }
 
+// SetCopies sets the number of copies of the book in stock.
+// It takes a pointer receiver `*Book` because it needs to modify the original book.
+// It returns an error if the number of copies is negative, leaving the book unchanged.
+// Note that assigning the exported Copies field directly bypasses this check.
+func (b *Book) SetCopies(n int) error {
+	if n < 0 {
+		return fmt.Errorf("negative number of copies %d", n)
+	}
+	b.Copies = n
+	return nil
+}
+
+// SetDiscountPercent sets the discount applied to the book's price.
+// It takes a pointer receiver `*Book` because it needs to modify the original book.
+// It returns an error if the percentage isn't between 0 and 100.
+func (b *Book) SetDiscountPercent(pct int) error {
+	if err := validateDiscountPercent(pct); err != nil {
+		return err
+	}
+	b.DiscountPercent = pct
+	return nil
+}
+
+// validateDiscountPercent checks that a discount is a percentage between 0 and 100.
+func validateDiscountPercent(pct int) error {
+	if pct < 0 || pct > 100 {
+		return fmt.Errorf("discount %d%% is not between 0%% and 100%%", pct)
+	}
+	return nil
+}
+
+// ApplyDiscountToAll sets the same discount on every book of the catalog, e.g. for a store-wide sale.
+// The percentage is validated before any book is touched: on error, the catalog is left unchanged.
+func (c Catalog) ApplyDiscountToAll(pct int) error {
+	if err := validateDiscountPercent(pct); err != nil {
+		return err
+	}
+	for id, b := range c {
+		// The map holds copies of the books: update the copy, then store it back.
+		if err := b.SetDiscountPercent(pct); err != nil {
+			return err
+		}
+		c[id] = b
+	}
+	return nil
+}
+
+// AdjustPrices multiplies the price of every book of the catalog by factor, e.g. 1.1 to follow a 10% inflation,
+// or 0.9 to lower all the prices by 10%. The new prices are rounded to the nearest cent, halfway values up.
+// Discounts are percentages: they're left as they are, and apply to the new prices.
+// The factor must be a positive number. Every new price is checked before any book is touched:
+// on error, the catalog is left unchanged.
+func (c Catalog) AdjustPrices(factor float64) error {
+	// NaN fails every comparison: it has to be checked separately. The infinity is an invalid factor too.
+	if factor <= 0 || math.IsNaN(factor) || math.IsInf(factor, 0) {
+		return fmt.Errorf("invalid price factor %g, must be a positive number", factor)
+	}
+
+	// Prepare the updated books first, and only store them once they're all valid.
+	updated := make(map[int]Book, len(c))
+	for id, b := range c {
+		price := math.Round(float64(b.PriceCents) * factor)
+		// Converting a float64 too large for an int gives a meaningless result.
+		if price >= math.MaxInt {
+			return fmt.Errorf("book %d: adjusted price %.0f cents is too large", id, price)
+		}
+		if err := b.SetPriceCents(int(price)); err != nil {
+			return fmt.Errorf("book %d: %w", id, err)
+		}
+		updated[id] = b
+	}
+
+	for id, b := range updated {
+		c[id] = b
+	}
+	return nil
+}
+
 // SetCategory sets the category for the book.
 // It takes a pointer receiver `*Book` because it needs to modify the original book's `category` field.
 // It validates the provided category against the list of valid categories.
@@ -158,3 +461,8 @@ func (b *Book) SetCategory(category Category) error {
 func (b Book) Category() Category {
 	return b.category
 }
+
+// Archived tells whether the book was archived with Catalog.Archive: it's hidden from the catalog's listings.
+func (b Book) Archived() bool {
+	return b.archived
+}
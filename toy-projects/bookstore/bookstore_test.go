@@ -5,13 +5,63 @@ package bookstore_test
 
 import (
 	"bookstore" // Import the package we are testing.
+	"fmt"       // Used to name the books of generated catalogs.
+	"math"      // Used to build invalid factors.
 	"sort"      // Used for sorting slices in tests for consistent comparison.
+	"strings"   // Used to look for details in error messages.
 	"testing"   // Go's built-in testing package.
+	"time"      // Used to drive the catalog's clock.
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
+// TestNewBook tests the NewBook constructor with valid input.
+func TestNewBook(t *testing.T) {
+	t.Parallel()
+
+	want := &bookstore.Book{
+		ID:     1,
+		Title:  "For the Love of Go",
+		Author: "John Arundel",
+	}
+
+	got, err := bookstore.NewBook(1, "For the Love of Go", "John Arundel")
+	if err != nil {
+		t.Fatalf("NewBook returned unexpected error: %v", err)
+	}
+
+	if !cmp.Equal(want, got, cmpopts.IgnoreUnexported(bookstore.Book{})) {
+		t.Error(cmp.Diff(want, got, cmpopts.IgnoreUnexported(bookstore.Book{})))
+	}
+}
+
+// TestNewBookInvalid tests the NewBook constructor with invalid input.
+func TestNewBookInvalid(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		id    int
+		title string
+	}{
+		"empty title": {id: 1, title: ""},
+		"zero ID":     {id: 0, title: "For the Love of Go"},
+		"negative ID": {id: -1, title: "For the Love of Go"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := bookstore.NewBook(tc.id, tc.title, "John Arundel")
+			if err == nil {
+				t.Fatalf("NewBook(%d, %q): want error, got nil", tc.id, tc.title)
+			}
+			if got != nil {
+				t.Errorf("NewBook(%d, %q): want nil book on error, got %#v", tc.id, tc.title, got)
+			}
+		})
+	}
+}
+
 // TestBuy tests the Buy function.
 // It checks if buying a book correctly decrements the copies count.
 func TestBuy(t *testing.T) {
@@ -126,6 +176,46 @@ func TestAddBook(t *testing.T) {
 	}
 }
 
+// TestAddBooks tests that every book without a conflict is added, and that each conflict gets its own error.
+func TestAddBooks(t *testing.T) {
+	t.Parallel()
+
+	catalog := bookstore.Catalog{
+		1: {ID: 1, Title: "Already There"},
+	}
+	books := []bookstore.Book{
+		{ID: 2, Title: "New Book"},
+		{ID: 1, Title: "Clashes With The Catalog"},
+		{ID: 3, Title: "Another New Book"},
+		{ID: 3, Title: "Clashes With The List"},
+	}
+
+	errs := catalog.AddBooks(books)
+	if len(errs) != len(books) {
+		t.Fatalf("want %d errors, got %d", len(books), len(errs))
+	}
+
+	wantFailed := []bool{false, true, false, true}
+	for i, err := range errs {
+		if failed := err != nil; failed != wantFailed[i] {
+			t.Errorf("book %d (%s): want failure %t, got error %v", i, books[i].Title, wantFailed[i], err)
+		}
+		if err != nil && !strings.Contains(err.Error(), "already exists") {
+			t.Errorf("book %d: want an error containing 'already exists', got %v", i, err)
+		}
+	}
+
+	// The conflicting books didn't replace the ones already in the catalog.
+	want := map[int]string{1: "Already There", 2: "New Book", 3: "Another New Book"}
+	got := map[int]string{}
+	for id, b := range catalog {
+		got[id] = b.Title
+	}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
 // TestGetAllBooks tests the GetAllBooks method of the Catalog type.
 // It checks if the method returns all books currently in the catalog.
 func TestGetAllBooks(t *testing.T) {
@@ -334,3 +424,784 @@ func TestSetCategoryInvalid(t *testing.T) {
 		t.Fatal("want error for invalid category, got nil")
 	}
 }
+
+// TestSetCopies tests the SetCopies method for valid, negative and zero numbers of copies.
+func TestSetCopies(t *testing.T) {
+	t.Parallel()
+
+	b := bookstore.Book{Title: "For the Love of Go", Copies: 5}
+
+	if err := b.SetCopies(2); err != nil {
+		t.Fatal(err)
+	}
+	if b.Copies != 2 {
+		t.Errorf("want 2 copies, got %d", b.Copies)
+	}
+
+	if err := b.SetCopies(-1); err == nil {
+		t.Error("want error setting invalid copies -1, got nil")
+	}
+	if b.Copies != 2 {
+		t.Errorf("invalid copies changed the book: want 2 copies, got %d", b.Copies)
+	}
+
+	// Being out of stock is valid.
+	if err := b.SetCopies(0); err != nil {
+		t.Fatal(err)
+	}
+	if b.Copies != 0 {
+		t.Errorf("want 0 copies, got %d", b.Copies)
+	}
+}
+
+// TestSetDiscountPercent tests the SetDiscountPercent method for valid and invalid input.
+func TestSetDiscountPercent(t *testing.T) {
+	t.Parallel()
+
+	b := bookstore.Book{Title: "For the Love of Go"}
+
+	if err := b.SetDiscountPercent(25); err != nil {
+		t.Fatal(err)
+	}
+	if b.DiscountPercent != 25 {
+		t.Errorf("want discount 25, got %d", b.DiscountPercent)
+	}
+
+	for _, pct := range []int{-1, 101} {
+		if err := b.SetDiscountPercent(pct); err == nil {
+			t.Errorf("want error setting invalid discount %d, got nil", pct)
+		}
+	}
+	if b.DiscountPercent != 25 {
+		t.Errorf("invalid discounts changed the book: want discount 25, got %d", b.DiscountPercent)
+	}
+}
+
+// TestApplyDiscountToAll tests that a valid discount is applied to every book of the catalog.
+func TestApplyDiscountToAll(t *testing.T) {
+	t.Parallel()
+
+	catalog := bookstore.Catalog{
+		1: {ID: 1, Title: "For the Love of Go", DiscountPercent: 10},
+		2: {ID: 2, Title: "The Power of Go: Tools"},
+	}
+
+	err := catalog.ApplyDiscountToAll(30)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for id, b := range catalog {
+		if b.DiscountPercent != 30 {
+			t.Errorf("book %d: want discount 30, got %d", id, b.DiscountPercent)
+		}
+	}
+}
+
+// TestApplyDiscountToAllInvalid tests that an invalid discount leaves every book untouched.
+func TestApplyDiscountToAllInvalid(t *testing.T) {
+	t.Parallel()
+
+	catalog := bookstore.Catalog{
+		1: {ID: 1, Title: "For the Love of Go", DiscountPercent: 10},
+		2: {ID: 2, Title: "The Power of Go: Tools"},
+	}
+	want := bookstore.Catalog{
+		1: {ID: 1, Title: "For the Love of Go", DiscountPercent: 10},
+		2: {ID: 2, Title: "The Power of Go: Tools"},
+	}
+
+	err := catalog.ApplyDiscountToAll(150)
+	if err == nil {
+		t.Fatal("want error for invalid discount 150, got nil")
+	}
+
+	if !cmp.Equal(want, catalog, cmpopts.IgnoreUnexported(bookstore.Book{})) {
+		t.Error(cmp.Diff(want, catalog, cmpopts.IgnoreUnexported(bookstore.Book{})))
+	}
+}
+
+// TestAdjustPrices tests that every price is multiplied by the factor and rounded to the nearest cent.
+func TestAdjustPrices(t *testing.T) {
+	t.Parallel()
+
+	catalog := bookstore.Catalog{
+		1: {ID: 1, Title: "For the Love of Go", PriceCents: 4000},
+		2: {ID: 2, Title: "The Power of Go: Tools", PriceCents: 1999, DiscountPercent: 10},
+		3: {ID: 3, Title: "Spark Joy", PriceCents: 5},
+		4: {ID: 4, Title: "Free Sample"},
+	}
+	want := bookstore.Catalog{
+		1: {ID: 1, Title: "For the Love of Go", PriceCents: 4400},
+		// 1999 × 1.1 is 2198.9 cents: rounded up. The discount is kept.
+		2: {ID: 2, Title: "The Power of Go: Tools", PriceCents: 2199, DiscountPercent: 10},
+		// 5 × 1.1 is 5.5 cents: halfway values are rounded up.
+		3: {ID: 3, Title: "Spark Joy", PriceCents: 6},
+		4: {ID: 4, Title: "Free Sample"},
+	}
+
+	if err := catalog.AdjustPrices(1.1); err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(want, catalog, cmpopts.IgnoreUnexported(bookstore.Book{})) {
+		t.Error(cmp.Diff(want, catalog, cmpopts.IgnoreUnexported(bookstore.Book{})))
+	}
+}
+
+// TestAdjustPricesInvalid tests that an invalid factor, or an invalid resulting price, leaves every book untouched.
+func TestAdjustPricesInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, factor := range []float64{0, -1.1, math.NaN(), math.Inf(1)} {
+		catalog := bookstore.Catalog{
+			1: {ID: 1, Title: "For the Love of Go", PriceCents: 4000},
+		}
+		if err := catalog.AdjustPrices(factor); err == nil {
+			t.Errorf("want error for invalid factor %g, got nil", factor)
+		}
+		if got := catalog[1].PriceCents; got != 4000 {
+			t.Errorf("factor %g: want price unchanged at 4000, got %d", factor, got)
+		}
+	}
+
+	// A negative price, set directly through the exported field, is refused by SetPriceCents.
+	catalog := bookstore.Catalog{
+		1: {ID: 1, Title: "For the Love of Go", PriceCents: 4000},
+		2: {ID: 2, Title: "Misprint", PriceCents: -100},
+	}
+	if err := catalog.AdjustPrices(1.1); err == nil {
+		t.Error("want error for a negative price, got nil")
+	}
+	if got := catalog[1].PriceCents; got != 4000 {
+		t.Errorf("want price unchanged at 4000, got %d", got)
+	}
+}
+
+// TestClone tests that changing a cloned catalog doesn't change the original one.
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	catalog := bookstore.Catalog{
+		1: {ID: 1, Title: "For the Love of Go", Copies: 3},
+		2: {ID: 2, Title: "The Power of Go: Tools", Copies: 1},
+	}
+	want := bookstore.Catalog{
+		1: {ID: 1, Title: "For the Love of Go", Copies: 3},
+		2: {ID: 2, Title: "The Power of Go: Tools", Copies: 1},
+	}
+
+	clone := catalog.Clone()
+	if !cmp.Equal(want, clone, cmpopts.IgnoreUnexported(bookstore.Book{})) {
+		t.Fatal(cmp.Diff(want, clone, cmpopts.IgnoreUnexported(bookstore.Book{})))
+	}
+
+	// Buy a copy, retitle a book and add a new one, in the clone only.
+	b, err := bookstore.Buy(clone[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Title = "For the Love of Go, 2nd edition"
+	clone[1] = b
+	if err = clone.AddBook(bookstore.Book{ID: 3, Title: "Know Go: Generics"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(want, catalog, cmpopts.IgnoreUnexported(bookstore.Book{})) {
+		t.Error(cmp.Diff(want, catalog, cmpopts.IgnoreUnexported(bookstore.Book{})))
+	}
+}
+
+// TestTopValue tests that TopValue returns the cheapest books first, clamping n to the catalog size.
+func TestTopValue(t *testing.T) {
+	t.Parallel()
+
+	catalog := bookstore.Catalog{
+		1: {ID: 1, Title: "For the Love of Go", PriceCents: 4000, DiscountPercent: 50}, // net 2000
+		2: {ID: 2, Title: "The Power of Go: Tools", PriceCents: 3000},                  // net 3000
+		3: {ID: 3, Title: "Know Go: Generics", PriceCents: 2000},                       // net 2000, same as ID 1
+		4: {ID: 4, Title: "The Deeper Love of Go", PriceCents: 1500},                   // net 1500
+	}
+
+	testCases := map[string]struct {
+		n       int
+		wantIDs []int
+	}{
+		"smaller than the catalog": {n: 2, wantIDs: []int{4, 1}},
+		"equal to the catalog":     {n: 4, wantIDs: []int{4, 1, 3, 2}},
+		"larger than the catalog":  {n: 10, wantIDs: []int{4, 1, 3, 2}},
+		"zero":                     {n: 0, wantIDs: []int{}},
+		"negative":                 {n: -1, wantIDs: []int{}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := catalog.TopValue(tc.n)
+
+			gotIDs := []int{}
+			for _, b := range got {
+				gotIDs = append(gotIDs, b.ID)
+			}
+			if !cmp.Equal(tc.wantIDs, gotIDs) {
+				t.Error(cmp.Diff(tc.wantIDs, gotIDs))
+			}
+		})
+	}
+}
+
+// TestCountByCategory tests that books are counted per category, and that an empty catalog has no counts.
+func TestCountByCategory(t *testing.T) {
+	t.Parallel()
+
+	categories := map[int]bookstore.Category{
+		1: bookstore.CategoryAutobiography,
+		2: bookstore.CategoryLargePrintRomance,
+		3: bookstore.CategoryParticlePhysics,
+		4: bookstore.CategoryParticlePhysics,
+		5: bookstore.CategoryLargePrintRomance,
+		6: bookstore.CategoryParticlePhysics,
+	}
+	catalog := bookstore.Catalog{}
+	for id, category := range categories {
+		// The category is unexported: it can only be set through SetCategory.
+		b := bookstore.Book{ID: id, Title: fmt.Sprintf("Book %d", id)}
+		if err := b.SetCategory(category); err != nil {
+			t.Fatal(err)
+		}
+		catalog[id] = b
+	}
+	// Archived books aren't counted.
+	archived := bookstore.Book{ID: 7, Title: "Book 7"}
+	if err := archived.SetCategory(bookstore.CategoryAutobiography); err != nil {
+		t.Fatal(err)
+	}
+	catalog[7] = archived
+	if err := catalog.Archive(7); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[bookstore.Category]int{
+		bookstore.CategoryAutobiography:     1,
+		bookstore.CategoryLargePrintRomance: 2,
+		bookstore.CategoryParticlePhysics:   3,
+	}
+	got := catalog.CountByCategory()
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+
+	if got := (bookstore.Catalog{}).CountByCategory(); len(got) != 0 {
+		t.Errorf("want no counts for an empty catalog, got %v", got)
+	}
+}
+
+// TestAveragePriceByCategory tests that the mean net prices are computed per category.
+func TestAveragePriceByCategory(t *testing.T) {
+	t.Parallel()
+
+	books := []struct {
+		id         int
+		category   bookstore.Category
+		priceCents int
+		discount   int
+	}{
+		{id: 1, category: bookstore.CategoryAutobiography, priceCents: 1000},
+		{id: 2, category: bookstore.CategoryParticlePhysics, priceCents: 2000},
+		{id: 3, category: bookstore.CategoryParticlePhysics, priceCents: 4000, discount: 50},
+		{id: 4, category: bookstore.CategoryParticlePhysics, priceCents: 3500},
+		{id: 5, category: bookstore.CategoryAutobiography, priceCents: 1599},
+		{id: 6, category: bookstore.CategoryAutobiography, priceCents: 99999},
+	}
+	catalog := bookstore.Catalog{}
+	for _, book := range books {
+		b := bookstore.Book{ID: book.id, Title: fmt.Sprintf("Book %d", book.id), PriceCents: book.priceCents}
+		if err := b.SetCategory(book.category); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.SetDiscountPercent(book.discount); err != nil {
+			t.Fatal(err)
+		}
+		catalog[book.id] = b
+	}
+	// Archived books are left out of the averages.
+	if err := catalog.Archive(6); err != nil {
+		t.Fatal(err)
+	}
+
+	// Particle physics: (20.00 + 20.00 + 35.00) / 3, the second book being half price.
+	want := map[bookstore.Category]float64{
+		bookstore.CategoryAutobiography:   12.995,
+		bookstore.CategoryParticlePhysics: 25,
+	}
+	got := catalog.AveragePriceByCategory()
+	if !cmp.Equal(want, got, cmpopts.EquateApprox(0, 0.000001)) {
+		t.Error(cmp.Diff(want, got))
+	}
+
+	if got := (bookstore.Catalog{}).AveragePriceByCategory(); len(got) != 0 {
+		t.Errorf("want no averages for an empty catalog, got %v", got)
+	}
+}
+
+// TestSafeCatalogReserve tests that releasing a reservation restores the stock, and that committing it doesn't.
+func TestSafeCatalogReserve(t *testing.T) {
+	t.Parallel()
+
+	sc := bookstore.NewSafeCatalog(bookstore.Catalog{
+		1: {ID: 1, Title: "For the Love of Go", Copies: 5},
+	})
+
+	// wantCopies checks the number of copies of the book in stock.
+	wantCopies := func(want int) {
+		t.Helper()
+		b, err := sc.GetBook(1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b.Copies != want {
+			t.Errorf("want %d copies, got %d", want, b.Copies)
+		}
+	}
+
+	released, err := sc.Reserve(1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantCopies(3)
+	if err = sc.Release(released); err != nil {
+		t.Fatal(err)
+	}
+	wantCopies(5)
+
+	committed, err := sc.Reserve(1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = sc.Commit(committed); err != nil {
+		t.Fatal(err)
+	}
+	wantCopies(2)
+
+	// A reservation can only be finalized once.
+	if err = sc.Release(committed); err == nil {
+		t.Error("want error releasing a committed reservation, got nil")
+	}
+	if err = sc.Commit(released); err == nil {
+		t.Error("want error committing a released reservation, got nil")
+	}
+	wantCopies(2)
+}
+
+// TestSafeCatalogReserveInvalid tests that an invalid reservation fails without changing the stock.
+func TestSafeCatalogReserveInvalid(t *testing.T) {
+	t.Parallel()
+
+	sc := bookstore.NewSafeCatalog(bookstore.Catalog{
+		1: {ID: 1, Title: "For the Love of Go", Copies: 2},
+	})
+
+	if _, err := sc.Reserve(1, 3); err == nil {
+		t.Error("want error reserving more copies than available, got nil")
+	}
+	if _, err := sc.Reserve(1, 0); err == nil {
+		t.Error("want error reserving no copies, got nil")
+	}
+	if _, err := sc.Reserve(2, 1); err == nil {
+		t.Error("want error reserving a book that doesn't exist, got nil")
+	}
+
+	b, err := sc.GetBook(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Copies != 2 {
+		t.Errorf("failed reservations changed the stock: want 2 copies, got %d", b.Copies)
+	}
+}
+
+// TestDuplicateTitles tests that books sharing a title, whatever its case, are grouped, and that unique titles aren't reported.
+func TestDuplicateTitles(t *testing.T) {
+	t.Parallel()
+
+	catalog := bookstore.Catalog{
+		1: {ID: 1, Title: "For the Love of Go"},
+		2: {ID: 2, Title: "The Power of Go: Tools"},
+		7: {ID: 7, Title: "FOR THE LOVE OF GO"},
+		4: {ID: 4, Title: "for the love of go"},
+	}
+	// An archived book still counts as a duplicate.
+	if err := catalog.Archive(7); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string][]int{
+		"for the love of go": {1, 4, 7},
+	}
+	got := catalog.DuplicateTitles()
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+// TestInPriceRange tests that the books whose net price is in the range are returned, sorted by net price then ID.
+func TestInPriceRange(t *testing.T) {
+	t.Parallel()
+
+	catalog := bookstore.Catalog{
+		1: {ID: 1, Title: "For the Love of Go", PriceCents: 4000, DiscountPercent: 50}, // net 2000
+		2: {ID: 2, Title: "The Power of Go: Tools", PriceCents: 3000},                  // net 3000
+		3: {ID: 3, Title: "Know Go: Generics", PriceCents: 2000},                       // net 2000, same as ID 1
+		4: {ID: 4, Title: "The Deeper Love of Go", PriceCents: 1500},                   // net 1500
+		5: {ID: 5, Title: "Go in 1999", PriceCents: 1800},                              // net 1800, but archived
+	}
+	// Archived books can't be bought: they never show up, even when their price is in range.
+	if err := catalog.Archive(5); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]struct {
+		minCents, maxCents int
+		wantIDs            []int
+	}{
+		"some books":       {minCents: 1500, maxCents: 2000, wantIDs: []int{4, 1, 3}},
+		"single price":     {minCents: 3000, maxCents: 3000, wantIDs: []int{2}},
+		"no book in range": {minCents: 2100, maxCents: 2900, wantIDs: []int{}},
+		"whole catalog":    {minCents: 0, maxCents: 10000, wantIDs: []int{4, 1, 3, 2}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := catalog.InPriceRange(tc.minCents, tc.maxCents)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			gotIDs := []int{}
+			for _, b := range got {
+				gotIDs = append(gotIDs, b.ID)
+			}
+			if !cmp.Equal(tc.wantIDs, gotIDs) {
+				t.Error(cmp.Diff(tc.wantIDs, gotIDs))
+			}
+		})
+	}
+}
+
+// TestInPriceRangeInvalid tests that InPriceRange rejects negative and inverted bounds.
+func TestInPriceRangeInvalid(t *testing.T) {
+	t.Parallel()
+
+	catalog := bookstore.Catalog{1: {ID: 1, Title: "For the Love of Go", PriceCents: 4000}}
+
+	for _, bounds := range [][2]int{{-1, 100}, {0, -1}, {200, 100}} {
+		if _, err := catalog.InPriceRange(bounds[0], bounds[1]); err == nil {
+			t.Errorf("want error for price range %d-%d, got nil", bounds[0], bounds[1])
+		}
+	}
+}
+
+// TestSafeCatalogCheckout tests that a checkout buys every item, or none of them.
+func TestSafeCatalogCheckout(t *testing.T) {
+	t.Parallel()
+
+	newCatalog := func() *bookstore.SafeCatalog {
+		return bookstore.NewSafeCatalog(bookstore.Catalog{
+			1: {ID: 1, Title: "For the Love of Go", Copies: 5},
+			2: {ID: 2, Title: "The Power of Go: Tools", Copies: 1},
+		})
+	}
+
+	// copiesLeft returns the number of copies in stock of each book.
+	copiesLeft := func(sc *bookstore.SafeCatalog) map[int]int {
+		t.Helper()
+		left := map[int]int{}
+		for _, id := range []int{1, 2} {
+			b, err := sc.GetBook(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			left[id] = b.Copies
+		}
+		return left
+	}
+
+	t.Run("success", func(t *testing.T) {
+		sc := newCatalog()
+
+		got, err := sc.Checkout(map[int]int{1: 2, 2: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []bookstore.Book{
+			{ID: 1, Title: "For the Love of Go", Copies: 3},
+			{ID: 2, Title: "The Power of Go: Tools", Copies: 0},
+		}
+		if !cmp.Equal(want, got, cmpopts.IgnoreUnexported(bookstore.Book{})) {
+			t.Error(cmp.Diff(want, got, cmpopts.IgnoreUnexported(bookstore.Book{})))
+		}
+		if left := copiesLeft(sc); !cmp.Equal(map[int]int{1: 3, 2: 0}, left) {
+			t.Errorf("unexpected stock after checkout: %v", left)
+		}
+	})
+
+	t.Run("not enough copies of one item", func(t *testing.T) {
+		sc := newCatalog()
+
+		_, err := sc.Checkout(map[int]int{1: 2, 2: 3})
+		if err == nil {
+			t.Fatal("want error buying more copies than available, got nil")
+		}
+		if !strings.Contains(err.Error(), "book 2: 2 copies short") {
+			t.Errorf("want the shortfall in the error, got %q", err)
+		}
+		if left := copiesLeft(sc); !cmp.Equal(map[int]int{1: 5, 2: 1}, left) {
+			t.Errorf("failed checkout changed the stock: %v", left)
+		}
+	})
+
+	t.Run("unknown ID", func(t *testing.T) {
+		sc := newCatalog()
+
+		if _, err := sc.Checkout(map[int]int{1: 1, 3: 1}); err == nil {
+			t.Fatal("want error buying a book that doesn't exist, got nil")
+		}
+		if left := copiesLeft(sc); !cmp.Equal(map[int]int{1: 5, 2: 1}, left) {
+			t.Errorf("failed checkout changed the stock: %v", left)
+		}
+	})
+}
+
+// TestTable tests that the catalog is rendered as an aligned table, ordered by ID.
+func TestTable(t *testing.T) {
+	t.Parallel()
+	catalog := bookstore.Catalog{
+		2: {ID: 2, Title: "The Power of Go: Tools", Author: "John Arundel", Copies: 3, PriceCents: 4000, DiscountPercent: 25},
+		1: {ID: 1, Title: "For the Love of Go", Author: "John Arundel", Copies: 12, PriceCents: 1999},
+	}
+
+	want := "" +
+		"ID  Title                   Author        Copies  Net Price\n" +
+		"1   For the Love of Go      John Arundel  12      $19.99\n" +
+		"2   The Power of Go: Tools  John Arundel  3       $30.00\n"
+	if got := catalog.Table(); got != want {
+		t.Errorf("want table:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+// TestTable_Empty tests that an empty catalog renders only the header.
+func TestTable_Empty(t *testing.T) {
+	t.Parallel()
+	want := "ID  Title  Author  Copies  Net Price\n"
+	if got := (bookstore.Catalog{}).Table(); got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+// TestSafeCatalogAuditLog tests that every stock change is recorded, in order, with its delta.
+func TestSafeCatalogAuditLog(t *testing.T) {
+	t.Parallel()
+
+	// tick is a clock that moves a minute forward every time it's read.
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	ticks := 0
+	tick := func() time.Time {
+		ticks++
+		return start.Add(time.Duration(ticks) * time.Minute)
+	}
+
+	sc := bookstore.NewSafeCatalog(bookstore.Catalog{
+		1: {ID: 1, Title: "For the Love of Go", Copies: 5},
+	}, bookstore.WithClock(tick))
+
+	if err := sc.AddBook(bookstore.Book{ID: 2, Title: "The Power of Go: Tools", Copies: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sc.Checkout(map[int]int{1: 2, 2: 1}); err != nil {
+		t.Fatal(err)
+	}
+	r, err := sc.Reserve(1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sc.Release(r); err != nil {
+		t.Fatal(err)
+	}
+	if err := sc.Restock(2, 10); err != nil {
+		t.Fatal(err)
+	}
+	// Failed operations change nothing, and aren't recorded.
+	if err := sc.Restock(3, 1); err == nil {
+		t.Fatal("want error restocking a book that doesn't exist, got nil")
+	}
+	if _, err := sc.Checkout(map[int]int{1: 100}); err == nil {
+		t.Fatal("want error buying more copies than available, got nil")
+	}
+
+	minute := func(n int) time.Time { return start.Add(time.Duration(n) * time.Minute) }
+	want := []bookstore.AuditEntry{
+		{Time: minute(1), Action: bookstore.AuditAdd, BookID: 2, Delta: 3},
+		{Time: minute(2), Action: bookstore.AuditBuy, BookID: 1, Delta: -2},
+		{Time: minute(3), Action: bookstore.AuditBuy, BookID: 2, Delta: -1},
+		{Time: minute(4), Action: bookstore.AuditReserve, BookID: 1, Delta: -3},
+		{Time: minute(5), Action: bookstore.AuditRelease, BookID: 1, Delta: 3},
+		{Time: minute(6), Action: bookstore.AuditRestock, BookID: 2, Delta: 10},
+	}
+	got := sc.AuditLog()
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+
+	// Summing the deltas gives the stock changes since the catalog was created.
+	b, err := sc.GetBook(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Copies != 3-1+10 {
+		t.Errorf("want 12 copies of book 2, got %d", b.Copies)
+	}
+}
+
+// TestSafeCatalogRemove tests that removing a book deletes it and records its copies leaving the stock.
+func TestSafeCatalogRemove(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	sc := bookstore.NewSafeCatalog(bookstore.Catalog{
+		1: {ID: 1, Title: "For the Love of Go", Copies: 5},
+		2: {ID: 2, Title: "The Power of Go: Tools", Copies: 3},
+	}, bookstore.WithClock(func() time.Time { return at }))
+
+	if err := sc.Remove(1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sc.GetBook(1); err == nil {
+		t.Error("want error getting a removed book, got nil")
+	}
+	// Failed removals change nothing, and aren't recorded.
+	if err := sc.Remove(1); err == nil {
+		t.Error("want error removing a book that doesn't exist, got nil")
+	}
+	r, err := sc.Reserve(2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sc.Remove(2); err == nil {
+		t.Error("want error removing a book with a pending reservation, got nil")
+	}
+	if _, err := sc.GetBook(2); err != nil {
+		t.Errorf("want book 2 kept after a failed removal, got %v", err)
+	}
+	if err := sc.Commit(r); err != nil {
+		t.Fatal(err)
+	}
+	if err := sc.Remove(2); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []bookstore.AuditEntry{
+		{Time: at, Action: bookstore.AuditRemove, BookID: 1, Delta: -5},
+		{Time: at, Action: bookstore.AuditReserve, BookID: 2, Delta: -1},
+		{Time: at, Action: bookstore.AuditRemove, BookID: 2, Delta: -2},
+	}
+	got := sc.AuditLog()
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+// TestArchive tests that archived books are hidden from the listings, but can still be fetched and unarchived.
+func TestArchive(t *testing.T) {
+	t.Parallel()
+	catalog := bookstore.Catalog{
+		1: {ID: 1, Title: "For the Love of Go"},
+		2: {ID: 2, Title: "The Power of Go: Tools"},
+	}
+
+	if err := catalog.Archive(2); err != nil {
+		t.Fatal(err)
+	}
+
+	// ids returns the sorted IDs of the books.
+	ids := func(books []bookstore.Book) []int {
+		result := []int{}
+		for _, b := range books {
+			result = append(result, b.ID)
+		}
+		sort.Ints(result)
+		return result
+	}
+
+	if got := ids(catalog.GetAllBooks()); !cmp.Equal([]int{1}, got) {
+		t.Errorf("want only book 1 listed, got %v", got)
+	}
+	if got := ids(catalog.GetAllBooksIncludingArchived()); !cmp.Equal([]int{1, 2}, got) {
+		t.Errorf("want books 1 and 2 listed, got %v", got)
+	}
+
+	b, err := catalog.GetBook(2)
+	if err != nil {
+		t.Fatalf("want archived book to be found, got %v", err)
+	}
+	if !b.Archived() {
+		t.Error("want book 2 to be archived")
+	}
+
+	if err := catalog.Unarchive(2); err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(catalog.GetAllBooks()); !cmp.Equal([]int{1, 2}, got) {
+		t.Errorf("want books 1 and 2 listed after unarchiving, got %v", got)
+	}
+	if b, _ := catalog.GetBook(2); b.Archived() {
+		t.Error("want book 2 not to be archived anymore")
+	}
+}
+
+// TestArchiveInvalidID tests that archiving or unarchiving a book that doesn't exist fails.
+func TestArchiveInvalidID(t *testing.T) {
+	t.Parallel()
+	catalog := bookstore.Catalog{1: {ID: 1, Title: "For the Love of Go"}}
+
+	if err := catalog.Archive(999); err == nil {
+		t.Error("want error archiving a book that doesn't exist, got nil")
+	}
+	if err := catalog.Unarchive(999); err == nil {
+		t.Error("want error unarchiving a book that doesn't exist, got nil")
+	}
+	if len(catalog) != 1 {
+		t.Errorf("want the catalog unchanged, got %v", catalog)
+	}
+}
+
+// TestByAuthor tests that books are matched by author case-insensitively, and sorted by title.
+func TestByAuthor(t *testing.T) {
+	t.Parallel()
+	catalog := bookstore.Catalog{
+		1: {ID: 1, Title: "The Power of Go: Tools", Author: "John Arundel"},
+		2: {ID: 2, Title: "For the Love of Go", Author: "john arundel "},
+		3: {ID: 3, Title: "Spark Joy", Author: "Marie Kondo"},
+		4: {ID: 4, Title: "Know Go", Author: "JOHN ARUNDEL"},
+	}
+
+	testCases := map[string]struct {
+		author string
+		want   []int
+	}{
+		"several books":    {author: "John Arundel", want: []int{2, 4, 1}},
+		"case-insensitive": {author: "  mARIE kONDO ", want: []int{3}},
+		"no match":         {author: "Rob Pike", want: []int{}},
+		"empty author":     {author: "  ", want: []int{}},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := []int{}
+			for _, b := range catalog.ByAuthor(tc.author) {
+				got = append(got, b.ID)
+			}
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("want books %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package bookstore
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ReservationID identifies a reservation made on a SafeCatalog.
+type ReservationID int
+
+// reservation remembers which book, and how many of its copies, a reservation holds.
+type reservation struct {
+	bookID int
+	copies int
+}
+
+// SafeCatalog is a catalog that can be used by several goroutines at once, e.g. by concurrent checkouts.
+// A plain Catalog is a map: reading and writing it from several goroutines at the same time is a data race.
+// SafeCatalog guards its catalog with a mutex, so that only one goroutine touches it at a time.
+type SafeCatalog struct {
+	mu           sync.Mutex
+	catalog      Catalog
+	reservations map[ReservationID]reservation
+	// lastID is the ID of the latest reservation. IDs are never reused.
+	lastID ReservationID
+	// now returns the current time, time.Now by default. It timestamps the audit log.
+	now func() time.Time
+	// audit holds every stock change made through the catalog, oldest first.
+	audit []AuditEntry
+}
+
+// NewSafeCatalog returns a SafeCatalog holding a copy of the given catalog.
+// Copying it means that changes to the original catalog don't bypass the mutex.
+// It also takes a list of configuration functions to tune it at your will.
+func NewSafeCatalog(catalog Catalog, opts ...SafeCatalogOption) *SafeCatalog {
+	sc := &SafeCatalog{
+		catalog:      catalog.Clone(),
+		reservations: make(map[ReservationID]reservation),
+		now:          time.Now,
+	}
+
+	for _, configFunc := range opts {
+		configFunc(sc)
+	}
+
+	return sc
+}
+
+// AddBook adds a book to the catalog, like Catalog.AddBook.
+// It returns an error if a book with the same ID already exists.
+func (sc *SafeCatalog) AddBook(book Book) error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if err := sc.catalog.AddBook(book); err != nil {
+		return err
+	}
+	sc.record(AuditAdd, book.ID, book.Copies)
+	return nil
+}
+
+// Restock puts n more copies of a book in stock, e.g. when a delivery arrives.
+// It returns an error, and leaves the stock unchanged, if the book doesn't exist or if n isn't positive.
+func (sc *SafeCatalog) Restock(id int, n int) error {
+	if n <= 0 {
+		return fmt.Errorf("non-positive number of copies %d", n)
+	}
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	b, err := sc.catalog.GetBook(id)
+	if err != nil {
+		return err
+	}
+
+	b.Copies += n
+	sc.catalog[id] = b
+	sc.record(AuditRestock, id, n)
+	return nil
+}
+
+// Remove takes a book out of the catalog, along with the copies it has in stock.
+// It returns an error, and leaves the catalog unchanged, if the book doesn't exist
+// or if some of its copies are reserved: release or commit the reservations first,
+// otherwise releasing them later would put copies of a book that no longer exists back in stock.
+func (sc *SafeCatalog) Remove(id int) error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	b, err := sc.catalog.GetBook(id)
+	if err != nil {
+		return err
+	}
+	for rid, r := range sc.reservations {
+		if r.bookID == id {
+			return fmt.Errorf("book %d has pending reservation %d, can't remove it", id, rid)
+		}
+	}
+
+	delete(sc.catalog, id)
+	sc.record(AuditRemove, id, -b.Copies)
+	return nil
+}
+
+// GetBook retrieves a single book from the catalog by its ID, like Catalog.GetBook.
+// Reserved copies aren't counted in the book's Copies.
+func (sc *SafeCatalog) GetBook(id int) (Book, error) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	return sc.catalog.GetBook(id)
+}
+
+// Reserve holds n copies of a book, e.g. while a customer checks out.
+// The copies are taken out of stock right away, so nobody else can buy them,
+// until the reservation is either committed (the copies are sold) or released (they're back in stock).
+// It returns an error, and leaves the stock unchanged, if the book doesn't exist,
+// if n isn't positive, or if there aren't enough copies available.
+func (sc *SafeCatalog) Reserve(id int, n int) (ReservationID, error) {
+	if n <= 0 {
+		return 0, fmt.Errorf("non-positive number of copies %d", n)
+	}
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	b, err := sc.catalog.GetBook(id)
+	if err != nil {
+		return 0, err
+	}
+	if b.Copies < n {
+		return 0, fmt.Errorf("only %d copies of book %d left, can't reserve %d", b.Copies, id, n)
+	}
+
+	// The map holds copies of the books: update the copy, then store it back.
+	b.Copies -= n
+	sc.catalog[id] = b
+	sc.record(AuditReserve, id, -n)
+
+	sc.lastID++
+	sc.reservations[sc.lastID] = reservation{bookID: id, copies: n}
+	return sc.lastID, nil
+}
+
+// Release cancels a reservation, putting its copies back in stock.
+// It returns an error if the reservation doesn't exist, or was already released or committed.
+func (sc *SafeCatalog) Release(id ReservationID) error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	r, err := sc.takeReservation(id)
+	if err != nil {
+		return err
+	}
+
+	b := sc.catalog[r.bookID]
+	b.Copies += r.copies
+	sc.catalog[r.bookID] = b
+	sc.record(AuditRelease, r.bookID, r.copies)
+	return nil
+}
+
+// Commit finalizes a reservation: its copies are sold, and won't come back in stock.
+// It returns an error if the reservation doesn't exist, or was already released or committed.
+func (sc *SafeCatalog) Commit(id ReservationID) error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	// The copies were taken out of stock by Reserve: forgetting the reservation is all there is left to do.
+	_, err := sc.takeReservation(id)
+	return err
+}
+
+// Checkout buys several books at once, e.g. the contents of a shopping cart: items maps book IDs to quantities.
+// It's all or nothing: every item is checked before any stock is touched, so if one of them can't be bought,
+// nothing is, and the returned error lists every problem (unknown IDs, invalid quantities, missing copies).
+// On success, it returns the bought books with their updated stock, in ascending ID order.
+func (sc *SafeCatalog) Checkout(items map[int]int) ([]Book, error) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	ids := make([]int, 0, len(items))
+	for id := range items {
+		ids = append(ids, id)
+	}
+	// Map iteration order is random: sort the IDs to get a stable result, and stable error messages.
+	sort.Ints(ids)
+
+	var problems []error
+	for _, id := range ids {
+		quantity := items[id]
+		b, err := sc.catalog.GetBook(id)
+		switch {
+		case err != nil:
+			problems = append(problems, err)
+		case quantity <= 0:
+			problems = append(problems, fmt.Errorf("non-positive quantity %d for book %d", quantity, id))
+		case b.Copies < quantity:
+			problems = append(problems, fmt.Errorf("book %d: %d copies short (wanted %d, %d left)", id, quantity-b.Copies, quantity, b.Copies))
+		}
+	}
+	// errors.Join returns nil when there are no problems.
+	if err := errors.Join(problems...); err != nil {
+		return nil, fmt.Errorf("checkout failed: %w", err)
+	}
+
+	// Everything was checked while holding the lock: nobody can have bought the copies in the meantime.
+	bought := make([]Book, 0, len(ids))
+	for _, id := range ids {
+		b := sc.catalog[id]
+		b.Copies -= items[id]
+		sc.catalog[id] = b
+		sc.record(AuditBuy, id, -items[id])
+		bought = append(bought, b)
+	}
+	return bought, nil
+}
+
+// takeReservation removes a reservation and returns it. The caller must hold the mutex.
+func (sc *SafeCatalog) takeReservation(id ReservationID) (reservation, error) {
+	r, ok := sc.reservations[id]
+	if !ok {
+		return reservation{}, fmt.Errorf("reservation %d doesn't exist", id)
+	}
+	delete(sc.reservations, id)
+	return r, nil
+}
@@ -0,0 +1,42 @@
+package bookstore
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter" // Used to align the columns of the table.
+)
+
+// Table renders the catalog as a text table, with aligned columns, for command-line output.
+// Books are listed by ascending ID, and net prices are shown in dollars, e.g. $12.34.
+// An empty catalog renders only the header line.
+func (c Catalog) Table() string {
+	books := c.GetAllBooks()
+	// GetAllBooks gives no order guarantee, so we sort the books ourselves.
+	sort.Slice(books, func(i, j int) bool {
+		return books[i].ID < books[j].ID
+	})
+
+	var sb strings.Builder
+	// A tabwriter pads the tab-separated cells of each line, so that the columns line up.
+	// Here, columns are separated by at least 2 spaces.
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTitle\tAuthor\tCopies\tNet Price")
+	for _, b := range books {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%d\t%s\n", b.ID, b.Title, b.Author, b.Copies, formatDollars(b.NetPriceCents()))
+	}
+	// Flush writes the buffered lines once the width of every column is known.
+	// Writing to a strings.Builder never fails.
+	_ = tw.Flush()
+
+	return sb.String()
+}
+
+// formatDollars formats a price in cents as dollars, e.g. 1234 as $12.34.
+func formatDollars(cents int) string {
+	sign := ""
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	return fmt.Sprintf("%s$%d.%02d", sign, cents/100, cents%100)
+}
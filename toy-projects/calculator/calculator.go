@@ -5,6 +5,9 @@ import (
 	"math"
 )
 
+// ErrOverflow is returned when an integer operation doesn't fit in an int64.
+var ErrOverflow = errors.New("integer overflow")
+
 func Add(a, b float64) float64 {
 
 	return a + b
@@ -33,3 +36,68 @@ func Sqrt(a float64) (float64, error) {
 	}
 	return math.Sqrt(a), nil
 }
+
+// AddInt adds two integers, returning ErrOverflow instead of silently wrapping around.
+func AddInt(a, b int64) (int64, error) {
+	sum := a + b
+	// Adding two numbers of the same sign can't change the sign, unless it wrapped around.
+	if (a >= 0) == (b >= 0) && (sum >= 0) != (a >= 0) {
+		return 0, ErrOverflow
+	}
+	return sum, nil
+}
+
+// MulInt multiplies two integers, returning ErrOverflow instead of silently wrapping around.
+func MulInt(a, b int64) (int64, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	product := a * b
+	// -1 * MinInt64 wraps back to MinInt64, which the division check below can't catch.
+	if (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, ErrOverflow
+	}
+	if product/b != a {
+		return 0, ErrOverflow
+	}
+	return product, nil
+}
+
+// Round rounds x to the given number of decimal places, halfway values being rounded away from zero.
+// Negative places round to the left of the decimal point: -2 rounds to the nearest hundred.
+// It returns an error if places is so large that 10^places doesn't fit in a float64.
+func Round(x float64, places int) (float64, error) {
+	scale := math.Pow10(places)
+	if math.IsInf(scale, 0) || scale == 0 {
+		return 0, errors.New("too many decimal places to round to")
+	}
+
+	scaled := x * scale
+	// A number this large has no digits left to round at the requested place.
+	if math.IsInf(scaled, 0) {
+		return x, nil
+	}
+
+	return math.Round(scaled) / scale, nil
+}
+
+// Dot returns the dot product of two vectors, which must have the same length.
+func Dot(a, b []float64) (float64, error) {
+	if len(a) != len(b) {
+		return 0, errors.New("vectors of different lengths")
+	}
+
+	var sum float64
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum, nil
+}
+
+// Magnitude returns the length (Euclidean norm) of a vector.
+func Magnitude(v []float64) float64 {
+	// The dot product of a vector with itself can't fail, and is never negative.
+	squares, _ := Dot(v, v)
+	magnitude, _ := Sqrt(squares)
+	return magnitude
+}
@@ -7,6 +7,7 @@ package calculator_test
 
 import (
 	"calculator" // The package we are testing.
+	"errors"     // Used for errors.Is when checking for ErrOverflow.
 	"math"       // Used for math.Abs in closeEnough.
 	"testing"    // Go's built-in testing package.
 )
@@ -180,6 +181,179 @@ func TestSqrtInvalid(t *testing.T) {
 	}
 }
 
+// TestAddInt tests the AddInt function, including the int64 boundaries.
+func TestAddInt(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name string
+		a, b int64
+		want int64
+		err  error
+	}
+	testCases := []testCase{
+		{name: "positive numbers", a: 2, b: 3, want: 5},
+		{name: "negative numbers", a: -2, b: -3, want: -5},
+		{name: "mixed signs", a: math.MaxInt64, b: math.MinInt64, want: -1},
+		{name: "up to max", a: math.MaxInt64 - 1, b: 1, want: math.MaxInt64},
+		{name: "down to min", a: math.MinInt64 + 1, b: -1, want: math.MinInt64},
+		{name: "overflow", a: math.MaxInt64, b: 1, err: calculator.ErrOverflow},
+		{name: "underflow", a: math.MinInt64, b: -1, err: calculator.ErrOverflow},
+		{name: "max plus max", a: math.MaxInt64, b: math.MaxInt64, err: calculator.ErrOverflow},
+		{name: "min plus min", a: math.MinInt64, b: math.MinInt64, err: calculator.ErrOverflow},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := calculator.AddInt(tc.a, tc.b)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("AddInt(%d, %d): want error %v, got %v", tc.a, tc.b, tc.err, err)
+			}
+			if tc.want != got {
+				t.Errorf("AddInt(%d, %d): want %d, got %d", tc.a, tc.b, tc.want, got)
+			}
+		})
+	}
+}
+
+// TestMulInt tests the MulInt function, including the int64 boundaries.
+func TestMulInt(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name string
+		a, b int64
+		want int64
+		err  error
+	}
+	testCases := []testCase{
+		{name: "positive numbers", a: 6, b: 7, want: 42},
+		{name: "negative numbers", a: -6, b: -7, want: 42},
+		{name: "mixed signs", a: 6, b: -7, want: -42},
+		{name: "by zero", a: math.MaxInt64, b: 0, want: 0},
+		{name: "max by one", a: math.MaxInt64, b: 1, want: math.MaxInt64},
+		{name: "min by one", a: math.MinInt64, b: 1, want: math.MinInt64},
+		{name: "max by minus one", a: math.MaxInt64, b: -1, want: -math.MaxInt64},
+		{name: "overflow", a: math.MaxInt64, b: 2, err: calculator.ErrOverflow},
+		{name: "underflow", a: math.MinInt64, b: 2, err: calculator.ErrOverflow},
+		{name: "min by minus one", a: math.MinInt64, b: -1, err: calculator.ErrOverflow},
+		{name: "minus one by min", a: -1, b: math.MinInt64, err: calculator.ErrOverflow},
+		{name: "min by min", a: math.MinInt64, b: math.MinInt64, err: calculator.ErrOverflow},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := calculator.MulInt(tc.a, tc.b)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("MulInt(%d, %d): want error %v, got %v", tc.a, tc.b, tc.err, err)
+			}
+			if tc.want != got {
+				t.Errorf("MulInt(%d, %d): want %d, got %d", tc.a, tc.b, tc.want, got)
+			}
+		})
+	}
+}
+
+// TestRound tests the Round function for valid inputs.
+func TestRound(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name   string
+		x      float64
+		places int
+		want   float64
+	}
+	testCases := []testCase{
+		{name: "round down", x: 3.14159, places: 2, want: 3.14},
+		{name: "round up", x: 2.71828, places: 3, want: 2.718},
+		{name: "round up to integer", x: 7.6, places: 0, want: 8},
+		{name: "halfway rounds away from zero", x: 2.5, places: 0, want: 3},
+		{name: "negative halfway rounds away from zero", x: -2.5, places: 0, want: -3},
+		{name: "round to tens", x: 1234.5, places: -1, want: 1230},
+		{name: "round to hundreds", x: 1250, places: -2, want: 1300},
+		{name: "round to thousands", x: -1499, places: -3, want: -1000},
+		{name: "more places than digits", x: 0.5, places: 5, want: 0.5},
+		{name: "too large to round", x: math.MaxFloat64, places: 2, want: math.MaxFloat64},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := calculator.Round(tc.x, tc.places)
+			if err != nil {
+				t.Fatalf("Round(%f, %d): unexpected error: %v", tc.x, tc.places, err)
+			}
+			if tc.want != got {
+				t.Errorf("Round(%f, %d): want %f, got %f", tc.x, tc.places, tc.want, got)
+			}
+		})
+	}
+}
+
+// TestRoundInvalid tests the Round function for places that can't be represented.
+func TestRoundInvalid(t *testing.T) {
+	t.Parallel()
+	for _, places := range []int{400, -400} {
+		_, err := calculator.Round(1.5, places)
+		if err == nil {
+			t.Errorf("Round(1.5, %d): want error for extreme places, got nil", places)
+		}
+	}
+}
+
+// TestDot tests the Dot function for vectors of the same length.
+func TestDot(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name string
+		a, b []float64
+		want float64
+	}
+	testCases := []testCase{
+		{name: "equal-length vectors", a: []float64{1, 2, 3}, b: []float64{4, -5, 6}, want: 12},
+		{name: "orthogonal vectors", a: []float64{1, 0}, b: []float64{0, 1}, want: 0},
+		{name: "empty vectors", a: []float64{}, b: []float64{}, want: 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := calculator.Dot(tc.a, tc.b)
+			if err != nil {
+				t.Fatalf("Dot(%v, %v): unexpected error: %v", tc.a, tc.b, err)
+			}
+			if tc.want != got {
+				t.Errorf("Dot(%v, %v): want %f, got %f", tc.a, tc.b, tc.want, got)
+			}
+		})
+	}
+}
+
+// TestDotInvalid tests the Dot function for vectors of different lengths.
+func TestDotInvalid(t *testing.T) {
+	t.Parallel()
+	_, err := calculator.Dot([]float64{1, 2}, []float64{1, 2, 3})
+	if err == nil {
+		t.Error("Dot: want error for length mismatch, got nil")
+	}
+}
+
+// TestMagnitude tests the Magnitude function.
+func TestMagnitude(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name string
+		v    []float64
+		want float64
+	}
+	testCases := []testCase{
+		{name: "3-4-5 triangle", v: []float64{3, 4}, want: 5},
+		{name: "zero vector", v: []float64{0, 0, 0}, want: 0},
+		{name: "unit vector", v: []float64{0, -1, 0}, want: 1},
+		{name: "3D vector", v: []float64{1, 2, 2}, want: 3},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := calculator.Magnitude(tc.v)
+			if !closeEnough(tc.want, got, 0.000001) {
+				t.Errorf("Magnitude(%v): want %f, got %f", tc.v, tc.want, got)
+			}
+		})
+	}
+}
+
 // closeEnough checks if two floating-point numbers are within a certain tolerance of each other.
 // This is necessary because floating-point arithmetic isn't always exact.
 // Parameters:
@@ -0,0 +1,56 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Integrate approximates the definite integral of f from a to b with the trapezoidal rule:
+// the interval is cut into steps slices, and the area under each slice is taken as a trapezoid.
+// More steps give a better approximation, at the cost of more calls to f.
+// If a is greater than b, the result is the negative of the integral from b to a.
+// It returns an error if steps isn't positive.
+func Integrate(f func(float64) float64, a, b float64, steps int) (float64, error) {
+	if steps <= 0 {
+		return 0, errors.New("number of steps must be positive")
+	}
+	if a > b {
+		integral, err := Integrate(f, b, a, steps)
+		return -integral, err
+	}
+
+	width := (b - a) / float64(steps)
+
+	// The ends of the interval belong to a single trapezoid each, every other point to two of them.
+	sum := (f(a) + f(b)) / 2
+	for i := 1; i < steps; i++ {
+		sum += f(a + float64(i)*width)
+	}
+	return sum * width, nil
+}
+
+// flatDerivative is how close to zero a derivative must be for FindRoot to give up:
+// following the tangent of a flat curve would throw the next guess arbitrarily far away.
+const flatDerivative = 1e-12
+
+// FindRoot looks for a root of f, a value x where f(x) is 0, with Newton's method:
+// starting from the guess, it repeatedly follows the tangent of f (given by its derivative fprime) down to zero.
+// It stops as soon as two successive guesses are less than tol apart.
+// It returns an error if it hasn't converged after maxIter iterations, or if the derivative gets too close to 0.
+func FindRoot(f, fprime func(float64) float64, guess float64, maxIter int, tol float64) (float64, error) {
+	x := guess
+	for range maxIter {
+		slope := fprime(x)
+		if math.Abs(slope) < flatDerivative {
+			return 0, fmt.Errorf("derivative is zero at %g", x)
+		}
+
+		next := x - f(x)/slope
+		if math.Abs(next-x) < tol {
+			return next, nil
+		}
+		x = next
+	}
+	return 0, fmt.Errorf("no convergence after %d iterations", maxIter)
+}
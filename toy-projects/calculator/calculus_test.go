@@ -0,0 +1,90 @@
+package calculator_test
+
+import (
+	"calculator"
+	"math"
+	"testing"
+)
+
+// TestIntegrate tests Integrate against integrals known exactly.
+func TestIntegrate(t *testing.T) {
+	t.Parallel()
+	identity := func(x float64) float64 { return x }
+	square := func(x float64) float64 { return x * x }
+
+	type testCase struct {
+		name      string
+		f         func(float64) float64
+		a, b      float64
+		steps     int
+		want      float64
+		tolerance float64
+	}
+	testCases := []testCase{
+		// The trapezoidal rule is exact for straight lines.
+		{name: "x over [0, 1]", f: identity, a: 0, b: 1, steps: 10, want: 0.5, tolerance: 0.000001},
+		{name: "x² over [0, 3]", f: square, a: 0, b: 3, steps: 1000, want: 9, tolerance: 0.0001},
+		{name: "reversed interval", f: square, a: 3, b: 0, steps: 1000, want: -9, tolerance: 0.0001},
+		{name: "empty interval", f: square, a: 2, b: 2, steps: 10, want: 0, tolerance: 0.000001},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := calculator.Integrate(tc.f, tc.a, tc.b, tc.steps)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !closeEnough(tc.want, got, tc.tolerance) {
+				t.Errorf("want %f, got %f", tc.want, got)
+			}
+		})
+	}
+}
+
+// TestIntegrateInvalidSteps tests that Integrate rejects a non-positive number of steps.
+func TestIntegrateInvalidSteps(t *testing.T) {
+	t.Parallel()
+	for _, steps := range []int{0, -1} {
+		_, err := calculator.Integrate(func(x float64) float64 { return x }, 0, 1, steps)
+		if err == nil {
+			t.Errorf("Integrate with %d steps: expected an error, got nil", steps)
+		}
+	}
+}
+
+// TestFindRoot tests that FindRoot finds √2 as the root of x² - 2.
+func TestFindRoot(t *testing.T) {
+	t.Parallel()
+	f := func(x float64) float64 { return x*x - 2 }
+	fprime := func(x float64) float64 { return 2 * x }
+
+	got, err := calculator.FindRoot(f, fprime, 1, 50, 1e-12)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !closeEnough(math.Sqrt2, got, 0.000001) {
+		t.Errorf("want %f, got %f", math.Sqrt2, got)
+	}
+}
+
+// TestFindRootInvalid tests that FindRoot reports the cases where Newton's method fails.
+func TestFindRootInvalid(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name      string
+		f, fprime func(float64) float64
+		guess     float64
+	}
+	testCases := []testCase{
+		// x² + 1 has no real root: the guesses jump around forever.
+		{name: "no convergence", f: func(x float64) float64 { return x*x + 1 }, fprime: func(x float64) float64 { return 2 * x }, guess: 0.5},
+		// The tangent of x² - 2 at 0 is horizontal: it never crosses zero.
+		{name: "zero derivative", f: func(x float64) float64 { return x*x - 2 }, fprime: func(x float64) float64 { return 2 * x }, guess: 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := calculator.FindRoot(tc.f, tc.fprime, tc.guess, 50, 1e-12); err == nil {
+				t.Error("expected an error, got nil")
+			}
+		})
+	}
+}
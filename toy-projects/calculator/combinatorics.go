@@ -0,0 +1,43 @@
+package calculator
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Combinations returns the number of ways to choose r items among n, when the order doesn't matter:
+// C(n, r) = n! / (r! * (n-r)!). For example, there are C(5, 2) = 10 ways to pick 2 cards out of 5.
+// The result is a *big.Int, because it quickly outgrows int64: C(100, 50) has 30 digits.
+// It returns an error if n or r is negative, or if r is greater than n.
+func Combinations(n, r int) (*big.Int, error) {
+	if err := checkCombinatorics(n, r); err != nil {
+		return nil, err
+	}
+	return new(big.Int).Binomial(int64(n), int64(r)), nil
+}
+
+// Permutations returns the number of ways to arrange r items chosen among n, when the order matters:
+// P(n, r) = n! / (n-r)!. For example, there are P(5, 2) = 20 ways to award gold and silver to 5 runners.
+// The result is a *big.Int, because it quickly outgrows int64.
+// It returns an error if n or r is negative, or if r is greater than n.
+func Permutations(n, r int) (*big.Int, error) {
+	if err := checkCombinatorics(n, r); err != nil {
+		return nil, err
+	}
+	// P(n, r) is the product of the r largest numbers up to n: (n-r+1) * ... * n.
+	// MulRange returns 1 for an empty range, which is P(n, 0).
+	return new(big.Int).MulRange(int64(n-r+1), int64(n)), nil
+}
+
+// checkCombinatorics returns an error if r items can't be chosen among n.
+func checkCombinatorics(n, r int) error {
+	switch {
+	case n < 0:
+		return fmt.Errorf("negative number of items: %d", n)
+	case r < 0:
+		return fmt.Errorf("negative number of chosen items: %d", r)
+	case r > n:
+		return fmt.Errorf("can't choose %d items among %d", r, n)
+	}
+	return nil
+}
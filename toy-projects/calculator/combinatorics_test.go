@@ -0,0 +1,85 @@
+package calculator_test
+
+import (
+	"calculator"
+	"math/big"
+	"testing"
+)
+
+// TestCombinations tests Combinations with known values and edge cases.
+func TestCombinations(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		n, r int
+		want string
+	}
+	testCases := []testCase{
+		{n: 5, r: 2, want: "10"},
+		{n: 5, r: 0, want: "1"},
+		{n: 5, r: 5, want: "1"},
+		{n: 0, r: 0, want: "1"},
+		{n: 52, r: 5, want: "2598960"},
+		// Way beyond int64.
+		{n: 100, r: 50, want: "100891344545564193334812497256"},
+	}
+	for _, tc := range testCases {
+		got, err := calculator.Combinations(tc.n, tc.r)
+		if err != nil {
+			t.Fatalf("Combinations(%d, %d): unexpected error: %v", tc.n, tc.r, err)
+		}
+		if got.String() != tc.want {
+			t.Errorf("Combinations(%d, %d): want %s, got %s", tc.n, tc.r, tc.want, got)
+		}
+	}
+}
+
+// TestPermutations tests Permutations with known values and edge cases.
+func TestPermutations(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		n, r int
+		want string
+	}
+	testCases := []testCase{
+		{n: 5, r: 2, want: "20"},
+		{n: 5, r: 0, want: "1"},
+		{n: 5, r: 5, want: "120"},
+		{n: 0, r: 0, want: "1"},
+		// 25! doesn't fit in an int64.
+		{n: 25, r: 25, want: "15511210043330985984000000"},
+	}
+	for _, tc := range testCases {
+		got, err := calculator.Permutations(tc.n, tc.r)
+		if err != nil {
+			t.Fatalf("Permutations(%d, %d): unexpected error: %v", tc.n, tc.r, err)
+		}
+		if got.String() != tc.want {
+			t.Errorf("Permutations(%d, %d): want %s, got %s", tc.n, tc.r, tc.want, got)
+		}
+	}
+}
+
+// TestCombinatoricsInvalid tests that Combinations and Permutations reject impossible choices.
+func TestCombinatoricsInvalid(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name string
+		n, r int
+	}
+	testCases := []testCase{
+		{name: "negative n", n: -1, r: 0},
+		{name: "negative r", n: 5, r: -1},
+		{name: "r greater than n", n: 2, r: 3},
+	}
+	funcs := map[string]func(n, r int) (*big.Int, error){
+		"Combinations": calculator.Combinations,
+		"Permutations": calculator.Permutations,
+	}
+	for name, f := range funcs {
+		for _, tc := range testCases {
+			if _, err := f(tc.n, tc.r); err == nil {
+				t.Errorf("%s(%d, %d) (%s): expected an error, got nil", name, tc.n, tc.r, tc.name)
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// DecimalPlaces is the number of decimal places every Decimal has.
+const DecimalPlaces = 6
+
+// decimalScale is 10^DecimalPlaces: a Decimal stores its value multiplied by it.
+const decimalScale = 1_000_000
+
+// Decimal is an exact decimal number with DecimalPlaces decimal places, such as 0.1 or -12.345678.
+// float64 can't represent 0.1 exactly, so 0.1 + 0.2 gives 0.30000000000000004. A Decimal avoids that
+// by storing an integer number of millionths: 0.1 is 100000 millionths, and adding integers is exact.
+// The zero value is 0.
+type Decimal struct {
+	// millionths is the value of the decimal, multiplied by decimalScale.
+	millionths int64
+}
+
+// NewDecimal parses a decimal written with an optional sign, digits, and up to DecimalPlaces decimals,
+// such as "42", "-0.5" or "3.141592".
+// It returns an error if the text isn't such a number, or if it has too many decimals or digits.
+func NewDecimal(value string) (Decimal, error) {
+	negative := strings.HasPrefix(value, "-")
+	digits := strings.TrimPrefix(value, "-")
+
+	integer, fraction, hasPoint := strings.Cut(digits, ".")
+	if integer == "" || (hasPoint && fraction == "") {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", value)
+	}
+	if len(fraction) > DecimalPlaces {
+		return Decimal{}, fmt.Errorf("decimal %q has more than %d decimal places", value, DecimalPlaces)
+	}
+
+	// ParseUint rejects signs and anything that isn't a digit, which leaves only the digits we expect.
+	// The fraction is padded with zeros to count millionths: "5" is 500000 millionths.
+	whole, err := strconv.ParseUint(integer, 10, 63)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("invalid decimal %q: %w", value, err)
+	}
+	fractionDigits := fraction + strings.Repeat("0", DecimalPlaces-len(fraction))
+	part, err := strconv.ParseUint(fractionDigits, 10, 63)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("invalid decimal %q: %w", value, err)
+	}
+
+	if whole > (math.MaxInt64-part)/decimalScale {
+		return Decimal{}, fmt.Errorf("decimal %q: %w", value, ErrOverflow)
+	}
+	millionths := int64(whole*decimalScale + part)
+	if negative {
+		millionths = -millionths
+	}
+	return Decimal{millionths: millionths}, nil
+}
+
+// Add returns d + other, exactly. It returns ErrOverflow if the sum is too large for a Decimal.
+func (d Decimal) Add(other Decimal) (Decimal, error) {
+	sum, err := AddInt(d.millionths, other.millionths)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return Decimal{millionths: sum}, nil
+}
+
+// Sub returns d - other, exactly. It returns ErrOverflow if the difference is too large for a Decimal.
+func (d Decimal) Sub(other Decimal) (Decimal, error) {
+	// Negating the smallest int64 overflows: its opposite is one more than the largest int64.
+	if other.millionths == math.MinInt64 {
+		return Decimal{}, ErrOverflow
+	}
+	return d.Add(Decimal{millionths: -other.millionths})
+}
+
+// Mul returns d × other, rounded to DecimalPlaces decimal places, halfway values away from zero:
+// the exact product of two numbers with 6 decimals has 12 of them. 0.5 × 0.000001 is 0.000001.
+// It returns ErrOverflow if the product is too large for a Decimal.
+func (d Decimal) Mul(other Decimal) (Decimal, error) {
+	// The product of the millionths is in millionths of millionths: it's computed with a big.Int,
+	// which can't overflow, before dividing it back to millionths.
+	product := new(big.Int).Mul(big.NewInt(d.millionths), big.NewInt(other.millionths))
+	quotient, remainder := new(big.Int).QuoRem(product, big.NewInt(decimalScale), new(big.Int))
+
+	// QuoRem truncates towards zero: move one further away from zero when the remainder is at least half.
+	if new(big.Int).Abs(remainder).Int64()*2 >= decimalScale {
+		quotient.Add(quotient, big.NewInt(int64(product.Sign())))
+	}
+
+	if !quotient.IsInt64() {
+		return Decimal{}, ErrOverflow
+	}
+	return Decimal{millionths: quotient.Int64()}, nil
+}
+
+// String returns the decimal in the format NewDecimal reads, without trailing zeros: 1.500000 is "1.5".
+func (d Decimal) String() string {
+	sign := ""
+	// The absolute value is computed as a uint64, which has room for the opposite of the smallest int64.
+	abs := uint64(d.millionths)
+	if d.millionths < 0 {
+		sign, abs = "-", uint64(-d.millionths)
+	}
+
+	whole, fraction := abs/decimalScale, abs%decimalScale
+	if fraction == 0 {
+		return fmt.Sprintf("%s%d", sign, whole)
+	}
+	decimals := strings.TrimRight(fmt.Sprintf("%06d", fraction), "0")
+	return fmt.Sprintf("%s%d.%s", sign, whole, decimals)
+}
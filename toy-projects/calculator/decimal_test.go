@@ -0,0 +1,96 @@
+package calculator_test
+
+import (
+	"calculator"
+	"errors"
+	"testing"
+)
+
+// mustDecimal parses a decimal, failing the test if it can't.
+func mustDecimal(t *testing.T, value string) calculator.Decimal {
+	t.Helper()
+	d, err := calculator.NewDecimal(value)
+	if err != nil {
+		t.Fatalf("NewDecimal(%q): unexpected error: %v", value, err)
+	}
+	return d
+}
+
+// TestDecimalExactSum tests that 0.1 + 0.2 is exactly 0.3, unlike with float64.
+func TestDecimalExactSum(t *testing.T) {
+	t.Parallel()
+	sum, err := mustDecimal(t, "0.1").Add(mustDecimal(t, "0.2"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sum != mustDecimal(t, "0.3") {
+		t.Errorf("0.1 + 0.2: want 0.3, got %s", sum)
+	}
+}
+
+// TestDecimalArithmetic tests Add, Sub and Mul.
+func TestDecimalArithmetic(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		a, op, b, want string
+	}
+	testCases := []testCase{
+		{a: "1.5", op: "+", b: "2.25", want: "3.75"},
+		{a: "-1", op: "+", b: "0.000001", want: "-0.999999"},
+		{a: "10", op: "-", b: "0.01", want: "9.99"},
+		{a: "0.3", op: "-", b: "0.1", want: "0.2"},
+		{a: "1.5", op: "*", b: "-4", want: "-6"},
+		{a: "0.1", op: "*", b: "0.1", want: "0.01"},
+		// The exact product, 0.0000005, is rounded to 6 decimal places.
+		{a: "0.5", op: "*", b: "0.000001", want: "0.000001"},
+		{a: "-0.5", op: "*", b: "0.000001", want: "-0.000001"},
+		{a: "0.4", op: "*", b: "0.000001", want: "0"},
+		// The product of the millionths doesn't fit in an int64, but the result does.
+		{a: "123456.789", op: "*", b: "1000", want: "123456789"},
+	}
+	for _, tc := range testCases {
+		a, b := mustDecimal(t, tc.a), mustDecimal(t, tc.b)
+		var got calculator.Decimal
+		var err error
+		switch tc.op {
+		case "+":
+			got, err = a.Add(b)
+		case "-":
+			got, err = a.Sub(b)
+		case "*":
+			got, err = a.Mul(b)
+		}
+		if err != nil {
+			t.Fatalf("%s %s %s: unexpected error: %v", tc.a, tc.op, tc.b, err)
+		}
+		if got.String() != tc.want {
+			t.Errorf("%s %s %s: want %s, got %s", tc.a, tc.op, tc.b, tc.want, got)
+		}
+	}
+}
+
+// TestDecimalOverflow tests that operations too large for a Decimal return ErrOverflow.
+func TestDecimalOverflow(t *testing.T) {
+	t.Parallel()
+	huge := mustDecimal(t, "9000000000000")
+
+	if _, err := huge.Add(huge); !errors.Is(err, calculator.ErrOverflow) {
+		t.Errorf("Add: want ErrOverflow, got %v", err)
+	}
+	if _, err := mustDecimal(t, "-9000000000000").Sub(huge); !errors.Is(err, calculator.ErrOverflow) {
+		t.Errorf("Sub: want ErrOverflow, got %v", err)
+	}
+	if _, err := huge.Mul(huge); !errors.Is(err, calculator.ErrOverflow) {
+		t.Errorf("Mul: want ErrOverflow, got %v", err)
+	}
+}
+
+// TestNewDecimalInvalid tests that NewDecimal rejects text that isn't a decimal.
+func TestNewDecimalInvalid(t *testing.T) {
+	t.Parallel()
+	for _, value := range []string{"", "-", "abc", "1.2.3", "1.", ".5", "+1", "--1", "1e3", "1.1234567", "99999999999999"} {
+		if _, err := calculator.NewDecimal(value); err == nil {
+			t.Errorf("NewDecimal(%q): expected an error, got nil", value)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package calculator
+
+import (
+	"errors"
+	"math"
+)
+
+// CompoundInterest returns what a principal grows to after the given number of years,
+// the interest being compounded timesPerYear times a year.
+// The annual rate is a fraction: 0.05 stands for 5%.
+// It returns an error on negative inputs, or if the interest is never compounded.
+func CompoundInterest(principal, annualRate float64, timesPerYear, years int) (float64, error) {
+	if principal < 0 || annualRate < 0 || years < 0 {
+		return 0, errors.New("negative inputs not allowed")
+	}
+	if timesPerYear <= 0 {
+		return 0, errors.New("interest must be compounded at least once a year")
+	}
+
+	periodicRate := annualRate / float64(timesPerYear)
+	return principal * math.Pow(1+periodicRate, float64(timesPerYear*years)), nil
+}
+
+// MonthlyPayment returns the fixed monthly payment that pays off a loan over the given number of months.
+// The annual rate is a fraction: 0.06 stands for 6%.
+// It returns an error on negative inputs, or if the loan has no months to be paid off.
+func MonthlyPayment(principal, annualRate float64, months int) (float64, error) {
+	if principal < 0 || annualRate < 0 {
+		return 0, errors.New("negative inputs not allowed")
+	}
+	if months <= 0 {
+		return 0, errors.New("a loan must be paid off over at least one month")
+	}
+
+	monthlyRate := annualRate / 12
+	// Without interest, the principal is simply split evenly, and the formula below would divide by zero.
+	if monthlyRate == 0 {
+		return principal / float64(months), nil
+	}
+
+	growth := math.Pow(1+monthlyRate, float64(months))
+	return principal * monthlyRate * growth / (growth - 1), nil
+}
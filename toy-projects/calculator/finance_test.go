@@ -0,0 +1,85 @@
+package calculator_test
+
+import (
+	"calculator"
+	"testing"
+)
+
+// TestCompoundInterest tests CompoundInterest against textbook values.
+func TestCompoundInterest(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name         string
+		principal    float64
+		annualRate   float64
+		timesPerYear int
+		years        int
+		want         float64
+	}
+	testCases := []testCase{
+		{name: "compounded yearly", principal: 1000, annualRate: 0.05, timesPerYear: 1, years: 10, want: 1628.894627},
+		{name: "compounded monthly", principal: 1000, annualRate: 0.05, timesPerYear: 12, years: 10, want: 1647.009498},
+		{name: "no interest", principal: 1000, annualRate: 0, timesPerYear: 4, years: 10, want: 1000},
+		{name: "no time", principal: 1000, annualRate: 0.05, timesPerYear: 12, years: 0, want: 1000},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := calculator.CompoundInterest(tc.principal, tc.annualRate, tc.timesPerYear, tc.years)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !closeEnough(tc.want, got, 0.000001) {
+				t.Errorf("want %f, got %f", tc.want, got)
+			}
+		})
+	}
+}
+
+// TestMonthlyPayment tests MonthlyPayment against textbook values.
+func TestMonthlyPayment(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name       string
+		principal  float64
+		annualRate float64
+		months     int
+		want       float64
+	}
+	testCases := []testCase{
+		{name: "30-year mortgage", principal: 200000, annualRate: 0.06, months: 360, want: 1199.101050},
+		{name: "5-year car loan", principal: 20000, annualRate: 0.045, months: 60, want: 372.860385},
+		{name: "no interest", principal: 1200, annualRate: 0, months: 12, want: 100},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := calculator.MonthlyPayment(tc.principal, tc.annualRate, tc.months)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !closeEnough(tc.want, got, 0.000001) {
+				t.Errorf("want %f, got %f", tc.want, got)
+			}
+		})
+	}
+}
+
+// TestFinanceInvalidInput tests that the finance helpers reject negative inputs and zero periods.
+func TestFinanceInvalidInput(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]func() (float64, error){
+		"negative principal":      func() (float64, error) { return calculator.CompoundInterest(-1000, 0.05, 12, 10) },
+		"negative rate":           func() (float64, error) { return calculator.CompoundInterest(1000, -0.05, 12, 10) },
+		"negative years":          func() (float64, error) { return calculator.CompoundInterest(1000, 0.05, 12, -1) },
+		"never compounded":        func() (float64, error) { return calculator.CompoundInterest(1000, 0.05, 0, 10) },
+		"negative loan":           func() (float64, error) { return calculator.MonthlyPayment(-1000, 0.06, 12) },
+		"negative loan rate":      func() (float64, error) { return calculator.MonthlyPayment(1000, -0.06, 12) },
+		"loan without any months": func() (float64, error) { return calculator.MonthlyPayment(1000, 0.06, 0) },
+	}
+	for name, call := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := call(); err == nil {
+				t.Error("expected an error, got nil")
+			}
+		})
+	}
+}
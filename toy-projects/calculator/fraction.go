@@ -0,0 +1,71 @@
+package calculator
+
+import (
+	"errors"
+	"math/big"
+)
+
+// Fraction is an exact rational number, such as 1/3, which neither float64 nor Decimal can represent:
+// 1/3 + 1/3 + 1/3 is exactly 1. It's a thin wrapper over big.Rat, whose numerator and denominator
+// can grow as large as needed, so there's no overflow either.
+// Fractions are immutable: the methods return new fractions. The zero value is 0.
+type Fraction struct {
+	// rat holds the value. It's never modified once the Fraction is built,
+	// so that copies of a Fraction can share it. It's nil for the zero value.
+	rat *big.Rat
+}
+
+// NewFraction returns the fraction numerator/denominator, reduced to lowest terms: 2/4 is 1/2.
+// The sign is carried by the numerator: 1/-3 is -1/3.
+// It returns an error if the denominator is zero.
+func NewFraction(numerator, denominator int64) (Fraction, error) {
+	if denominator == 0 {
+		return Fraction{}, errors.New("zero denominator")
+	}
+	return Fraction{rat: big.NewRat(numerator, denominator)}, nil
+}
+
+// Add returns f + g.
+func (f Fraction) Add(g Fraction) Fraction {
+	return Fraction{rat: new(big.Rat).Add(f.value(), g.value())}
+}
+
+// Sub returns f - g.
+func (f Fraction) Sub(g Fraction) Fraction {
+	return Fraction{rat: new(big.Rat).Sub(f.value(), g.value())}
+}
+
+// Mul returns f × g.
+func (f Fraction) Mul(g Fraction) Fraction {
+	return Fraction{rat: new(big.Rat).Mul(f.value(), g.value())}
+}
+
+// Div returns f ÷ g. It returns an error if g is zero.
+func (f Fraction) Div(g Fraction) (Fraction, error) {
+	if g.value().Sign() == 0 {
+		return Fraction{}, errors.New("division by zero")
+	}
+	return Fraction{rat: new(big.Rat).Quo(f.value(), g.value())}, nil
+}
+
+// Float64 returns the float64 nearest to the fraction, e.g. 0.3333333333333333 for 1/3.
+// Unlike the fraction, it's usually not exact.
+func (f Fraction) Float64() float64 {
+	value, _ := f.value().Float64()
+	return value
+}
+
+// String returns the fraction in lowest terms, such as "1/3" or "-5/2".
+// Whole numbers are written without a denominator: "2", not "2/1".
+func (f Fraction) String() string {
+	return f.value().RatString()
+}
+
+// value returns the big.Rat of the fraction, which is 0 for the zero value.
+// The returned big.Rat must not be modified.
+func (f Fraction) value() *big.Rat {
+	if f.rat == nil {
+		return new(big.Rat)
+	}
+	return f.rat
+}
@@ -0,0 +1,96 @@
+package calculator_test
+
+import (
+	"calculator"
+	"testing"
+)
+
+// mustFraction builds a fraction, failing the test if it can't.
+func mustFraction(t *testing.T, numerator, denominator int64) calculator.Fraction {
+	t.Helper()
+	f, err := calculator.NewFraction(numerator, denominator)
+	if err != nil {
+		t.Fatalf("NewFraction(%d, %d): unexpected error: %v", numerator, denominator, err)
+	}
+	return f
+}
+
+// TestFractionExactSum tests that 1/3 + 1/3 + 1/3 is exactly 1, unlike with float64 or Decimal.
+func TestFractionExactSum(t *testing.T) {
+	t.Parallel()
+	third := mustFraction(t, 1, 3)
+	sum := third.Add(third).Add(third)
+	if sum.String() != "1" {
+		t.Errorf("1/3 + 1/3 + 1/3: want 1, got %s", sum)
+	}
+	if sum.Float64() != 1 {
+		t.Errorf("1/3 + 1/3 + 1/3: want 1.0, got %v", sum.Float64())
+	}
+}
+
+// TestFractionArithmetic tests Add, Sub, Mul and Div.
+func TestFractionArithmetic(t *testing.T) {
+	t.Parallel()
+	half, third := mustFraction(t, 1, 2), mustFraction(t, 1, 3)
+	type testCase struct {
+		name string
+		op   func() (calculator.Fraction, error)
+		want string
+	}
+	testCases := []testCase{
+		{name: "1/2 + 1/3", op: func() (calculator.Fraction, error) { return half.Add(third), nil }, want: "5/6"},
+		{name: "1/3 - 1/2", op: func() (calculator.Fraction, error) { return third.Sub(half), nil }, want: "-1/6"},
+		{name: "1/2 × 1/3", op: func() (calculator.Fraction, error) { return half.Mul(third), nil }, want: "1/6"},
+		{name: "1/2 ÷ 1/3", op: func() (calculator.Fraction, error) { return half.Div(third) }, want: "3/2"},
+		{name: "zero value + 1/3", op: func() (calculator.Fraction, error) { return calculator.Fraction{}.Add(third), nil }, want: "1/3"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.op()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.String() != tc.want {
+				t.Errorf("want %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+// TestFractionString tests that fractions are written in lowest terms, with the sign on the numerator.
+func TestFractionString(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		numerator, denominator int64
+		want                   string
+	}
+	testCases := []testCase{
+		{numerator: 1, denominator: 3, want: "1/3"},
+		{numerator: 2, denominator: 4, want: "1/2"},
+		{numerator: 1, denominator: -3, want: "-1/3"},
+		{numerator: -6, denominator: -3, want: "2"},
+		{numerator: 0, denominator: 5, want: "0"},
+	}
+	for _, tc := range testCases {
+		if got := mustFraction(t, tc.numerator, tc.denominator).String(); got != tc.want {
+			t.Errorf("%d/%d: want %s, got %s", tc.numerator, tc.denominator, tc.want, got)
+		}
+	}
+	if got := (calculator.Fraction{}).String(); got != "0" {
+		t.Errorf("zero value: want 0, got %s", got)
+	}
+}
+
+// TestFractionInvalid tests that zero denominators and divisions by zero are rejected.
+func TestFractionInvalid(t *testing.T) {
+	t.Parallel()
+	if _, err := calculator.NewFraction(1, 0); err == nil {
+		t.Error("NewFraction(1, 0): expected an error, got nil")
+	}
+	if _, err := mustFraction(t, 1, 2).Div(mustFraction(t, 0, 7)); err == nil {
+		t.Error("1/2 ÷ 0: expected an error, got nil")
+	}
+	if _, err := mustFraction(t, 1, 2).Div(calculator.Fraction{}); err == nil {
+		t.Error("1/2 ÷ zero value: expected an error, got nil")
+	}
+}
@@ -0,0 +1,49 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MatMul returns the matrix product of a and b, for small dense matrices given as slices of rows.
+// Multiplying an m×n matrix by an n×p matrix gives an m×p matrix, where each cell is the dot product
+// of a row of a and a column of b. The result is newly allocated: it never shares memory with a or b.
+// It returns an error if a matrix is empty or ragged (rows of different lengths),
+// or if the number of columns of a doesn't match the number of rows of b.
+func MatMul(a, b [][]float64) ([][]float64, error) {
+	aCols, err := columns(a)
+	if err != nil {
+		return nil, fmt.Errorf("first matrix: %w", err)
+	}
+	bCols, err := columns(b)
+	if err != nil {
+		return nil, fmt.Errorf("second matrix: %w", err)
+	}
+	if aCols != len(b) {
+		return nil, fmt.Errorf("can't multiply a %d×%d matrix by a %d×%d matrix", len(a), aCols, len(b), bCols)
+	}
+
+	product := make([][]float64, len(a))
+	for i := range product {
+		product[i] = make([]float64, bCols)
+		for j := range product[i] {
+			for k := range aCols {
+				product[i][j] += a[i][k] * b[k][j]
+			}
+		}
+	}
+	return product, nil
+}
+
+// columns returns the number of columns of a matrix, after checking that it's neither empty nor ragged.
+func columns(m [][]float64) (int, error) {
+	if len(m) == 0 || len(m[0]) == 0 {
+		return 0, errors.New("empty matrix")
+	}
+	for i, row := range m {
+		if len(row) != len(m[0]) {
+			return 0, fmt.Errorf("ragged matrix: row %d has %d columns, row 0 has %d", i, len(row), len(m[0]))
+		}
+	}
+	return len(m[0]), nil
+}
@@ -0,0 +1,84 @@
+package calculator_test
+
+import (
+	"calculator"
+	"reflect"
+	"testing"
+)
+
+// TestMatMul tests MatMul with a rectangular product and the identity matrix.
+func TestMatMul(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name string
+		a, b [][]float64
+		want [][]float64
+	}
+	testCases := []testCase{
+		{
+			name: "2x3 times 3x2",
+			a:    [][]float64{{1, 2, 3}, {4, 5, 6}},
+			b:    [][]float64{{7, 8}, {9, 10}, {11, 12}},
+			want: [][]float64{{58, 64}, {139, 154}},
+		},
+		{
+			name: "identity",
+			a:    [][]float64{{1, 0}, {0, 1}},
+			b:    [][]float64{{2.5, -1}, {3, 4}},
+			want: [][]float64{{2.5, -1}, {3, 4}},
+		},
+		{
+			name: "row times column",
+			a:    [][]float64{{1, 2, 3}},
+			b:    [][]float64{{4}, {5}, {6}},
+			want: [][]float64{{32}},
+		},
+	}
+	for _, tc := range testCases {
+		got, err := calculator.MatMul(tc.a, tc.b)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if !reflect.DeepEqual(tc.want, got) {
+			t.Errorf("%s: want %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+// TestMatMulDoesNotAlias tests that the product doesn't share memory with the inputs.
+func TestMatMulDoesNotAlias(t *testing.T) {
+	t.Parallel()
+	identity := [][]float64{{1, 0}, {0, 1}}
+	b := [][]float64{{1, 2}, {3, 4}}
+
+	got, err := calculator.MatMul(identity, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got[0][0] = 42
+
+	if b[0][0] != 1 {
+		t.Errorf("changing the product changed the input: %v", b)
+	}
+}
+
+// TestMatMulInvalid tests that MatMul rejects matrices that can't be multiplied.
+func TestMatMulInvalid(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name string
+		a, b [][]float64
+	}
+	testCases := []testCase{
+		{name: "dimension mismatch", a: [][]float64{{1, 2, 3}}, b: [][]float64{{1, 2}, {3, 4}}},
+		{name: "ragged first matrix", a: [][]float64{{1, 2}, {3}}, b: [][]float64{{1}, {2}}},
+		{name: "ragged second matrix", a: [][]float64{{1, 2}}, b: [][]float64{{1, 2}, {3}}},
+		{name: "empty matrix", a: [][]float64{}, b: [][]float64{{1}}},
+		{name: "empty rows", a: [][]float64{{}}, b: [][]float64{{1}}},
+	}
+	for _, tc := range testCases {
+		if _, err := calculator.MatMul(tc.a, tc.b); err == nil {
+			t.Errorf("%s: expected an error, got nil", tc.name)
+		}
+	}
+}
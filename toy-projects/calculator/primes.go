@@ -0,0 +1,49 @@
+package calculator
+
+import "fmt"
+
+// IsPrime tells whether n is a prime number: a number greater than 1 only divisible by 1 and itself.
+// It tries to divide n by every odd number up to its square root: a larger divisor
+// would come paired with a smaller one, which would already have been found.
+func IsPrime(n int64) bool {
+	if n < 2 {
+		return false
+	}
+	if n%2 == 0 {
+		return n == 2
+	}
+	// d <= n/d is d*d <= n, without the risk of d*d overflowing.
+	for d := int64(3); d <= n/d; d += 2 {
+		if n%d == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// PrimeFactors returns the prime factorization of n, in ascending order: 12 gives [2 2 3].
+// Multiplying the factors together gives n back.
+// It returns an error if n is less than 2, which has no prime factors.
+func PrimeFactors(n int64) ([]int64, error) {
+	if n < 2 {
+		return nil, fmt.Errorf("no prime factorization for %d", n)
+	}
+
+	var factors []int64
+	for n%2 == 0 {
+		factors = append(factors, 2)
+		n /= 2
+	}
+	// Dividing out each factor as soon as it's found means that only primes can divide what's left.
+	for d := int64(3); d <= n/d; d += 2 {
+		for n%d == 0 {
+			factors = append(factors, d)
+			n /= d
+		}
+	}
+	// What's left, if anything, has no divisor up to its square root: it's a prime.
+	if n > 1 {
+		factors = append(factors, n)
+	}
+	return factors, nil
+}
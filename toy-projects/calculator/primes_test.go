@@ -0,0 +1,67 @@
+package calculator_test
+
+import (
+	"calculator"
+	"slices"
+	"testing"
+)
+
+// TestIsPrime tests IsPrime with primes, composites and numbers below 2.
+func TestIsPrime(t *testing.T) {
+	t.Parallel()
+	testCases := map[int64]bool{
+		-7: false, 0: false, 1: false,
+		2: true, 3: true, 5: true, 7: true, 11: true, 13: true,
+		4: false, 9: false, 15: false, 21: false, 25: false, 91: false,
+		// A large prime, and the square of a large prime.
+		2147483647: true, 1000003 * 1000003: false,
+	}
+	for n, want := range testCases {
+		if got := calculator.IsPrime(n); got != want {
+			t.Errorf("IsPrime(%d): want %t, got %t", n, want, got)
+		}
+	}
+}
+
+// TestPrimeFactors tests PrimeFactors, and checks that the factors multiply back to the input.
+func TestPrimeFactors(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		n    int64
+		want []int64
+	}
+	testCases := []testCase{
+		{n: 2, want: []int64{2}},
+		{n: 12, want: []int64{2, 2, 3}},
+		{n: 97, want: []int64{97}},
+		{n: 360, want: []int64{2, 2, 2, 3, 3, 5}},
+		{n: 2147483647 * 3, want: []int64{3, 2147483647}},
+	}
+	for _, tc := range testCases {
+		got, err := calculator.PrimeFactors(tc.n)
+		if err != nil {
+			t.Fatalf("PrimeFactors(%d): unexpected error: %v", tc.n, err)
+		}
+		if !slices.Equal(tc.want, got) {
+			t.Errorf("PrimeFactors(%d): want %v, got %v", tc.n, tc.want, got)
+		}
+
+		product := int64(1)
+		for _, f := range got {
+			product *= f
+		}
+		if product != tc.n {
+			t.Errorf("PrimeFactors(%d): the factors multiply to %d", tc.n, product)
+		}
+	}
+}
+
+// TestPrimeFactorsInvalid tests that PrimeFactors rejects numbers below 2.
+func TestPrimeFactorsInvalid(t *testing.T) {
+	t.Parallel()
+	for _, n := range []int64{1, 0, -12} {
+		if _, err := calculator.PrimeFactors(n); err == nil {
+			t.Errorf("PrimeFactors(%d): expected an error, got nil", n)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// EvaluateRPN evaluates an expression written in reverse Polish (postfix) notation,
+// where each operator follows its two operands: "3 4 + 2 *" is (3 + 4) * 2.
+// Numbers are pushed onto a stack, and each operator replaces the two numbers on top of it with its result.
+// It returns an error if an operator lacks operands, if numbers are left over, on unknown tokens,
+// and on division by zero.
+func EvaluateRPN(tokens []string) (float64, error) {
+	var stack []float64
+
+	for _, token := range tokens {
+		operation, isOperator := rpnOperators[token]
+		if !isOperator {
+			number, err := strconv.ParseFloat(token, 64)
+			if err != nil {
+				return 0, fmt.Errorf("unknown token %q", token)
+			}
+			stack = append(stack, number)
+			continue
+		}
+
+		if len(stack) < 2 {
+			return 0, fmt.Errorf("not enough operands for %q", token)
+		}
+		// The operand on top of the stack is the right-hand side: "8 2 /" is 8 / 2.
+		a, b := stack[len(stack)-2], stack[len(stack)-1]
+		stack = stack[:len(stack)-2]
+
+		result, err := operation(a, b)
+		if err != nil {
+			return 0, err
+		}
+		stack = append(stack, result)
+	}
+
+	switch len(stack) {
+	case 0:
+		return 0, errors.New("empty expression")
+	case 1:
+		return stack[0], nil
+	default:
+		return 0, fmt.Errorf("%d operands left without an operator", len(stack)-1)
+	}
+}
+
+// rpnOperators maps the operators EvaluateRPN understands to the functions computing them.
+var rpnOperators = map[string]func(a, b float64) (float64, error){
+	"+": func(a, b float64) (float64, error) { return Add(a, b), nil },
+	"-": func(a, b float64) (float64, error) { return Subtract(a, b), nil },
+	"*": func(a, b float64) (float64, error) { return Multiply(a, b), nil },
+	"/": Divide,
+}
@@ -0,0 +1,52 @@
+package calculator_test
+
+import (
+	"calculator"
+	"testing"
+)
+
+// TestEvaluateRPN tests EvaluateRPN with valid expressions.
+func TestEvaluateRPN(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name   string
+		tokens []string
+		want   float64
+	}
+	testCases := []testCase{
+		{name: "single number", tokens: []string{"42"}, want: 42},
+		{name: "addition then multiplication", tokens: []string{"3", "4", "+", "2", "*"}, want: 14},
+		{name: "operand order", tokens: []string{"8", "2", "/", "1", "-"}, want: 3},
+		{name: "negative and decimal numbers", tokens: []string{"-1.5", "2", "*"}, want: -3},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := calculator.EvaluateRPN(tc.tokens)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !closeEnough(tc.want, got, 0.000001) {
+				t.Errorf("want %f, got %f", tc.want, got)
+			}
+		})
+	}
+}
+
+// TestEvaluateRPNInvalid tests that EvaluateRPN rejects malformed expressions.
+func TestEvaluateRPNInvalid(t *testing.T) {
+	t.Parallel()
+	testCases := map[string][]string{
+		"not enough operands": {"1", "+"},
+		"leftover operands":   {"1", "2", "3", "+"},
+		"unknown operator":    {"2", "3", "^"},
+		"division by zero":    {"1", "0", "/"},
+		"empty expression":    {},
+	}
+	for name, tokens := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := calculator.EvaluateRPN(tokens); err == nil {
+				t.Errorf("EvaluateRPN(%q): expected an error, got nil", tokens)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// WeightedMean returns the mean of values, where each value counts as much as its weight:
+// the sum of value × weight, divided by the sum of the weights.
+// With equal weights, it's the usual mean.
+// It returns an error if there are no values, if values and weights have different lengths,
+// if a weight is negative, or if all the weights are zero.
+func WeightedMean(values, weights []float64) (float64, error) {
+	if len(values) == 0 {
+		return 0, errors.New("no values")
+	}
+	if len(values) != len(weights) {
+		return 0, fmt.Errorf("%d values but %d weights", len(values), len(weights))
+	}
+
+	var weightedSum, totalWeight float64
+	for i, w := range weights {
+		if w < 0 {
+			return 0, fmt.Errorf("negative weight %g", w)
+		}
+		weightedSum += values[i] * w
+		totalWeight += w
+	}
+
+	if totalWeight == 0 {
+		return 0, errors.New("weights add up to zero")
+	}
+	return weightedSum / totalWeight, nil
+}
+
+// LinearFit returns the line y = slope × x + intercept that best fits the points (xs[i], ys[i]),
+// in the least-squares sense: it minimizes the sum of the squared vertical distances between the points and the line.
+// It returns an error if xs and ys have different lengths, if there are fewer than two points,
+// or if all the xs are equal, in which case the best line would be vertical.
+func LinearFit(xs, ys []float64) (slope, intercept float64, err error) {
+	if len(xs) != len(ys) {
+		return 0, 0, fmt.Errorf("%d xs but %d ys", len(xs), len(ys))
+	}
+	if len(xs) < 2 {
+		return 0, 0, fmt.Errorf("need at least 2 points, got %d", len(xs))
+	}
+
+	n := float64(len(xs))
+	var sumX, sumY float64
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+	}
+	meanX, meanY := sumX/n, sumY/n
+
+	// Working with the distances to the means, rather than with raw sums of squares,
+	// avoids subtracting large, nearly equal numbers, which loses precision.
+	var covariance, varianceX float64
+	for i := range xs {
+		dx := xs[i] - meanX
+		covariance += dx * (ys[i] - meanY)
+		varianceX += dx * dx
+	}
+
+	if varianceX == 0 {
+		return 0, 0, errors.New("all xs are equal: the line would be vertical")
+	}
+
+	slope = covariance / varianceX
+	// The best line always goes through the point of means.
+	intercept = meanY - slope*meanX
+	return slope, intercept, nil
+}
+
+// Histogram sorts values into buckets of equal width over the range from their minimum to their maximum.
+// It returns the number of values in each bucket, and the buckets+1 boundaries of the buckets, in increasing order:
+// bucket i holds the values from bounds[i], included, to bounds[i+1], excluded.
+// The maximum itself goes in the last bucket, so that every value is counted.
+// If all the values are equal, the range is empty: they all go in the first bucket.
+// It returns an error if there are no values, if buckets isn't positive, or if a value is infinite or NaN.
+func Histogram(values []float64, buckets int) ([]int, []float64, error) {
+	if buckets <= 0 {
+		return nil, nil, fmt.Errorf("need at least 1 bucket, got %d", buckets)
+	}
+	if len(values) == 0 {
+		return nil, nil, errors.New("no values")
+	}
+
+	lowest, highest := values[0], values[0]
+	for _, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, nil, fmt.Errorf("value %g isn't a finite number", v)
+		}
+		lowest = min(lowest, v)
+		highest = max(highest, v)
+	}
+
+	bounds := make([]float64, buckets+1)
+	for i := range bounds {
+		// A weighted average of the extremes stays between them, without computing the range itself:
+		// highest - lowest can overflow to +Inf, e.g. from -math.MaxFloat64 to math.MaxFloat64.
+		t := float64(i) / float64(buckets)
+		bounds[i] = lowest*(1-t) + highest*t
+	}
+	// Rounding errors could leave the last boundary slightly off the maximum.
+	bounds[buckets] = highest
+
+	counts := make([]int, buckets)
+	for _, v := range values {
+		// The bucket is found by comparing the value with the bounds themselves, rather than by computing
+		// its position in the range: rounding errors could then put a value equal to a bound in the bucket below.
+		// SearchFloat64s returns the index of the first bound that isn't below v: v starts that bucket if it's
+		// equal to the bound, otherwise it belongs to the previous one.
+		i := sort.SearchFloat64s(bounds, v)
+		if bounds[i] != v {
+			i--
+		}
+		// The maximum would start a bucket of its own, past the last one.
+		counts[min(i, buckets-1)]++
+	}
+	return counts, bounds, nil
+}
+
+// ErrNoObservations is returned by the accessors of Stats that have no meaning before a value is observed.
+var ErrNoObservations = errors.New("no values observed")
+
+// Stats summarizes a stream of numbers as they come, e.g. values read from a file or a socket,
+// without keeping them: its memory use doesn't depend on how many values it observed.
+// The zero value is ready to use, NewStats is there for readability.
+type Stats struct {
+	// count is the number of values observed.
+	count int
+	// sum is the sum of the values observed.
+	sum float64
+	// min and max are the extremes of the values observed. They're meaningless while count is 0.
+	min, max float64
+}
+
+// NewStats returns a Stats that hasn't observed any value yet.
+func NewStats() *Stats {
+	return &Stats{}
+}
+
+// Observe adds x to the values summarized by s.
+func (s *Stats) Observe(x float64) {
+	if s.count == 0 {
+		s.min, s.max = x, x
+	} else {
+		s.min = min(s.min, x)
+		s.max = max(s.max, x)
+	}
+	s.count++
+	s.sum += x
+}
+
+// Count returns the number of values observed so far.
+func (s *Stats) Count() int {
+	return s.count
+}
+
+// Sum returns the sum of the values observed so far, which is 0 when there are none.
+func (s *Stats) Sum() float64 {
+	return s.sum
+}
+
+// Min returns the smallest value observed so far.
+// It returns ErrNoObservations if no value was observed: 0 would look like a genuine minimum.
+func (s *Stats) Min() (float64, error) {
+	if s.count == 0 {
+		return 0, ErrNoObservations
+	}
+	return s.min, nil
+}
+
+// Max returns the largest value observed so far.
+// It returns ErrNoObservations if no value was observed.
+func (s *Stats) Max() (float64, error) {
+	if s.count == 0 {
+		return 0, ErrNoObservations
+	}
+	return s.max, nil
+}
+
+// Mean returns the mean of the values observed so far.
+// It returns ErrNoObservations if no value was observed, rather than dividing by zero.
+func (s *Stats) Mean() (float64, error) {
+	if s.count == 0 {
+		return 0, ErrNoObservations
+	}
+	return s.sum / float64(s.count), nil
+}
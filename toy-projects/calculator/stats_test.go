@@ -0,0 +1,253 @@
+package calculator_test
+
+import (
+	"calculator"
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+// TestWeightedMean tests WeightedMean with valid inputs.
+func TestWeightedMean(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name            string
+		values, weights []float64
+		want            float64
+	}
+	testCases := []testCase{
+		{name: "grades with coefficients", values: []float64{12, 15, 9}, weights: []float64{2, 1, 1}, want: 12},
+		{name: "equal weights", values: []float64{1, 2, 3, 4}, weights: []float64{1, 1, 1, 1}, want: 2.5},
+		{name: "some zero weights", values: []float64{10, 100}, weights: []float64{1, 0}, want: 10},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := calculator.WeightedMean(tc.values, tc.weights)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !closeEnough(tc.want, got, 0.000001) {
+				t.Errorf("want %f, got %f", tc.want, got)
+			}
+		})
+	}
+}
+
+// TestWeightedMeanInvalid tests that WeightedMean rejects invalid inputs.
+func TestWeightedMeanInvalid(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name            string
+		values, weights []float64
+	}
+	testCases := []testCase{
+		{name: "empty input", values: []float64{}, weights: []float64{}},
+		{name: "length mismatch", values: []float64{1, 2}, weights: []float64{1}},
+		{name: "all-zero weights", values: []float64{1, 2}, weights: []float64{0, 0}},
+		{name: "negative weight", values: []float64{1, 2}, weights: []float64{3, -1}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := calculator.WeightedMean(tc.values, tc.weights); err == nil {
+				t.Error("expected an error, got nil")
+			}
+		})
+	}
+}
+
+// TestLinearFit tests LinearFit with exact and noisy data.
+func TestLinearFit(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name             string
+		xs, ys           []float64
+		slope, intercept float64
+		tolerance        float64
+	}
+	testCases := []testCase{
+		{
+			name: "perfectly linear", xs: []float64{0, 1, 2, 3}, ys: []float64{1, 3, 5, 7},
+			slope: 2, intercept: 1, tolerance: 0.000001,
+		},
+		{
+			name: "two points", xs: []float64{-1, 1}, ys: []float64{4, 0},
+			slope: -2, intercept: 2, tolerance: 0.000001,
+		},
+		{
+			// y = 0.5x + 10, give or take 0.1.
+			name: "noisy", xs: []float64{0, 2, 4, 6, 8, 10}, ys: []float64{10.1, 10.9, 12.1, 12.9, 14.1, 14.9},
+			slope: 0.5, intercept: 10, tolerance: 0.1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			slope, intercept, err := calculator.LinearFit(tc.xs, tc.ys)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !closeEnough(tc.slope, slope, tc.tolerance) {
+				t.Errorf("slope: want %f, got %f", tc.slope, slope)
+			}
+			if !closeEnough(tc.intercept, intercept, tc.tolerance) {
+				t.Errorf("intercept: want %f, got %f", tc.intercept, intercept)
+			}
+		})
+	}
+}
+
+// TestLinearFitInvalid tests that LinearFit rejects data it can't fit a line to.
+func TestLinearFitInvalid(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name   string
+		xs, ys []float64
+	}
+	testCases := []testCase{
+		{name: "length mismatch", xs: []float64{1, 2, 3}, ys: []float64{1, 2}},
+		{name: "single point", xs: []float64{1}, ys: []float64{1}},
+		{name: "no points", xs: []float64{}, ys: []float64{}},
+		{name: "constant x", xs: []float64{2, 2, 2}, ys: []float64{1, 2, 3}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, err := calculator.LinearFit(tc.xs, tc.ys); err == nil {
+				t.Error("expected an error, got nil")
+			}
+		})
+	}
+}
+
+// TestHistogram tests Histogram with valid inputs.
+func TestHistogram(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name    string
+		values  []float64
+		buckets int
+		counts  []int
+		bounds  []float64
+	}
+	testCases := []testCase{
+		{
+			name: "uniform data", values: []float64{0, 1, 2, 3, 4, 5, 6, 7}, buckets: 4,
+			counts: []int{2, 2, 2, 2}, bounds: []float64{0, 1.75, 3.5, 5.25, 7},
+		},
+		{
+			name: "maximum in the last bucket", values: []float64{10, 20, 30}, buckets: 2,
+			counts: []int{1, 2}, bounds: []float64{10, 20, 30},
+		},
+		{
+			name: "single repeated value", values: []float64{4.2, 4.2, 4.2}, buckets: 3,
+			counts: []int{3, 0, 0}, bounds: []float64{4.2, 4.2, 4.2, 4.2},
+		},
+		{
+			name: "one bucket", values: []float64{-1, 5, 2}, buckets: 1,
+			counts: []int{3}, bounds: []float64{-1, 5},
+		},
+		{
+			// 0.7 is the third bound: it starts the third bucket, whatever the rounding of the computation.
+			name: "value on an inner bound", values: []float64{0.4, 0.7, 1, 0.5}, buckets: 4,
+			counts: []int{2, 0, 1, 1}, bounds: []float64{0.4, 0.55, 0.7, 0.85, 1},
+		},
+		{
+			name: "extreme finite values", values: []float64{-math.MaxFloat64, 0, math.MaxFloat64}, buckets: 2,
+			counts: []int{1, 2}, bounds: []float64{-math.MaxFloat64, 0, math.MaxFloat64},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			counts, bounds, err := calculator.Histogram(tc.values, tc.buckets)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(tc.counts, counts) {
+				t.Errorf("want counts %v, got %v", tc.counts, counts)
+			}
+			if len(tc.bounds) != len(bounds) {
+				t.Fatalf("want bounds %v, got %v", tc.bounds, bounds)
+			}
+			for i := range bounds {
+				if !closeEnough(tc.bounds[i], bounds[i], 0.000001) {
+					t.Errorf("want bounds %v, got %v", tc.bounds, bounds)
+					break
+				}
+			}
+		})
+	}
+}
+
+// TestHistogramInvalid tests that Histogram rejects invalid inputs.
+func TestHistogramInvalid(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name    string
+		values  []float64
+		buckets int
+	}
+	testCases := []testCase{
+		{name: "empty input", values: []float64{}, buckets: 3},
+		{name: "zero buckets", values: []float64{1, 2}, buckets: 0},
+		{name: "negative buckets", values: []float64{1, 2}, buckets: -2},
+		{name: "NaN value", values: []float64{1, math.NaN()}, buckets: 2},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, err := calculator.Histogram(tc.values, tc.buckets); err == nil {
+				t.Error("expected an error, got nil")
+			}
+		})
+	}
+}
+
+// TestStats tests the accessors of Stats after a stream of values.
+func TestStats(t *testing.T) {
+	t.Parallel()
+	s := calculator.NewStats()
+	for _, x := range []float64{4, -2.5, 10, 0, 3.5} {
+		s.Observe(x)
+	}
+
+	if got := s.Count(); got != 5 {
+		t.Errorf("Count: want 5, got %d", got)
+	}
+	if got := s.Sum(); !closeEnough(15, got, 0.000001) {
+		t.Errorf("Sum: want 15, got %f", got)
+	}
+
+	type testCase struct {
+		name     string
+		accessor func() (float64, error)
+		want     float64
+	}
+	testCases := []testCase{
+		{name: "Min", accessor: s.Min, want: -2.5},
+		{name: "Max", accessor: s.Max, want: 10},
+		{name: "Mean", accessor: s.Mean, want: 3},
+	}
+	for _, tc := range testCases {
+		got, err := tc.accessor()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if !closeEnough(tc.want, got, 0.000001) {
+			t.Errorf("%s: want %f, got %f", tc.name, tc.want, got)
+		}
+	}
+}
+
+// TestStatsEmpty tests that Stats reports an empty stream instead of inventing a minimum, maximum or mean.
+func TestStatsEmpty(t *testing.T) {
+	t.Parallel()
+	// The zero value works like NewStats.
+	for _, s := range []*calculator.Stats{calculator.NewStats(), {}} {
+		if s.Count() != 0 || s.Sum() != 0 {
+			t.Errorf("want a count and a sum of 0, got %d and %f", s.Count(), s.Sum())
+		}
+		for name, accessor := range map[string]func() (float64, error){"Min": s.Min, "Max": s.Max, "Mean": s.Mean} {
+			if _, err := accessor(); !errors.Is(err, calculator.ErrNoObservations) {
+				t.Errorf("%s: want %v, got %v", name, calculator.ErrNoObservations, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+)
+
+// TempUnit is a unit of temperature.
+type TempUnit int
+
+// The temperature units known to ConvertTemperature.
+const (
+	Celsius TempUnit = iota
+	Fahrenheit
+	Kelvin
+)
+
+// absoluteZeroCelsius is the lowest possible temperature, in degrees Celsius.
+const absoluteZeroCelsius = -273.15
+
+// ErrBelowAbsoluteZero is returned for a temperature colder than absolute zero, which can't exist.
+var ErrBelowAbsoluteZero = errors.New("temperature below absolute zero")
+
+// CelsiusToFahrenheit converts degrees Celsius to degrees Fahrenheit.
+func CelsiusToFahrenheit(c float64) float64 {
+	return c*9/5 + 32
+}
+
+// FahrenheitToCelsius converts degrees Fahrenheit to degrees Celsius.
+func FahrenheitToCelsius(f float64) float64 {
+	return (f - 32) * 5 / 9
+}
+
+// CelsiusToKelvin converts degrees Celsius to kelvins.
+func CelsiusToKelvin(c float64) float64 {
+	return c - absoluteZeroCelsius
+}
+
+// KelvinToCelsius converts kelvins to degrees Celsius.
+func KelvinToCelsius(k float64) float64 {
+	return k + absoluteZeroCelsius
+}
+
+// ConvertTemperature converts a temperature from a unit to another.
+// It returns an error for unknown units, and ErrBelowAbsoluteZero for temperatures colder than absolute zero.
+func ConvertTemperature(value float64, from, to TempUnit) (float64, error) {
+	// Every conversion goes through Celsius.
+	var celsius float64
+	switch from {
+	case Celsius:
+		celsius = value
+	case Fahrenheit:
+		celsius = FahrenheitToCelsius(value)
+	case Kelvin:
+		celsius = KelvinToCelsius(value)
+	default:
+		return 0, fmt.Errorf("unknown temperature unit %d", from)
+	}
+
+	if celsius < absoluteZeroCelsius {
+		return 0, ErrBelowAbsoluteZero
+	}
+
+	switch to {
+	case Celsius:
+		return celsius, nil
+	case Fahrenheit:
+		return CelsiusToFahrenheit(celsius), nil
+	case Kelvin:
+		return CelsiusToKelvin(celsius), nil
+	default:
+		return 0, fmt.Errorf("unknown temperature unit %d", to)
+	}
+}
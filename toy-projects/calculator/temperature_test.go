@@ -0,0 +1,89 @@
+package calculator_test
+
+import (
+	"calculator"
+	"errors"
+	"testing"
+)
+
+// TestTemperatureFunctions tests the unit-to-unit conversion functions against known reference points.
+func TestTemperatureFunctions(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name    string
+		convert func(float64) float64
+		in      float64
+		want    float64
+	}
+	testCases := []testCase{
+		{name: "freezing point in Fahrenheit", convert: calculator.CelsiusToFahrenheit, in: 0, want: 32},
+		{name: "boiling point in Fahrenheit", convert: calculator.CelsiusToFahrenheit, in: 100, want: 212},
+		{name: "freezing point from Fahrenheit", convert: calculator.FahrenheitToCelsius, in: 32, want: 0},
+		{name: "-40 is the same in both", convert: calculator.FahrenheitToCelsius, in: -40, want: -40},
+		{name: "freezing point in Kelvin", convert: calculator.CelsiusToKelvin, in: 0, want: 273.15},
+		{name: "absolute zero from Kelvin", convert: calculator.KelvinToCelsius, in: 0, want: -273.15},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.convert(tc.in)
+			if !closeEnough(tc.want, got, 0.000001) {
+				t.Errorf("want %f, got %f", tc.want, got)
+			}
+		})
+	}
+}
+
+// TestConvertTemperature tests the ConvertTemperature dispatcher for valid inputs.
+func TestConvertTemperature(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name     string
+		value    float64
+		from, to calculator.TempUnit
+		want     float64
+	}
+	testCases := []testCase{
+		{name: "Celsius to Fahrenheit", value: 0, from: calculator.Celsius, to: calculator.Fahrenheit, want: 32},
+		{name: "Celsius to Kelvin", value: 0, from: calculator.Celsius, to: calculator.Kelvin, want: 273.15},
+		{name: "Fahrenheit to Kelvin", value: 32, from: calculator.Fahrenheit, to: calculator.Kelvin, want: 273.15},
+		{name: "Kelvin to Fahrenheit", value: 273.15, from: calculator.Kelvin, to: calculator.Fahrenheit, want: 32},
+		{name: "same unit", value: 21.5, from: calculator.Celsius, to: calculator.Celsius, want: 21.5},
+		{name: "absolute zero", value: 0, from: calculator.Kelvin, to: calculator.Celsius, want: -273.15},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := calculator.ConvertTemperature(tc.value, tc.from, tc.to)
+			if err != nil {
+				t.Fatalf("ConvertTemperature(%f, %d, %d): unexpected error: %v", tc.value, tc.from, tc.to, err)
+			}
+			if !closeEnough(tc.want, got, 0.000001) {
+				t.Errorf("ConvertTemperature(%f, %d, %d): want %f, got %f", tc.value, tc.from, tc.to, tc.want, got)
+			}
+		})
+	}
+}
+
+// TestConvertTemperatureInvalid tests the ConvertTemperature dispatcher for invalid inputs.
+func TestConvertTemperatureInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := calculator.ConvertTemperature(-1, calculator.Kelvin, calculator.Celsius)
+	if !errors.Is(err, calculator.ErrBelowAbsoluteZero) {
+		t.Errorf("ConvertTemperature(-1 K): want %v, got %v", calculator.ErrBelowAbsoluteZero, err)
+	}
+
+	_, err = calculator.ConvertTemperature(-300, calculator.Celsius, calculator.Kelvin)
+	if !errors.Is(err, calculator.ErrBelowAbsoluteZero) {
+		t.Errorf("ConvertTemperature(-300 °C): want %v, got %v", calculator.ErrBelowAbsoluteZero, err)
+	}
+
+	_, err = calculator.ConvertTemperature(10, calculator.TempUnit(42), calculator.Celsius)
+	if err == nil {
+		t.Error("ConvertTemperature: want error for unknown source unit, got nil")
+	}
+
+	_, err = calculator.ConvertTemperature(10, calculator.Celsius, calculator.TempUnit(42))
+	if err == nil {
+		t.Error("ConvertTemperature: want error for unknown target unit, got nil")
+	}
+}
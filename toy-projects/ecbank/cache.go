@@ -0,0 +1,57 @@
+package ecbank
+
+import (
+	"sync"
+	"time"
+)
+
+// cache keeps the contents of the feeds, by URL, for a limited time.
+// A nil *cache is valid and never holds anything, which is how caching is disabled.
+type cache struct {
+	// mu protects entries: the Client can be used from several goroutines at once.
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cacheEntry
+}
+
+// cacheEntry is the content of a feed, and when it was fetched.
+type cacheEntry struct {
+	body      []byte
+	fetchedAt time.Time
+}
+
+// newCache returns an empty cache whose entries expire after ttl.
+func newCache(ttl time.Duration) *cache {
+	return &cache{
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+// get returns the content of the feed at the given URL, if it was fetched less than ttl before now.
+func (c *cache) get(url string, now time.Time) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, found := c.entries[url]
+	if !found || now.Sub(entry.fetchedAt) >= c.ttl {
+		return nil, false
+	}
+	return entry.body, true
+}
+
+// put stores the content of the feed at the given URL, fetched at the given time.
+func (c *cache) put(url string, body []byte, now time.Time) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[url] = cacheEntry{body: body, fetchedAt: now}
+}
@@ -2,8 +2,11 @@
 package ecbank
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	money "learning-go/moneyconverter"
 	"net/http"
 	"net/url"
@@ -30,28 +33,340 @@ const (
 	ErrUnknownStatusCode    = ECBError("ECB client: unknown status code received from ECB")
 )
 
+// ErrInvalidRate is returned when the feed lists the requested currency with a rate
+// that is missing, zero, negative or not a number. It wraps ErrUnexpectedFormat.
+var ErrInvalidRate = fmt.Errorf("%w: invalid rate", ErrUnexpectedFormat)
+
+// ErrTruncatedResponse is returned when the ECB's response stops before its end, e.g. when the connection drops
+// during the download. It wraps ErrUnexpectedFormat. Unlike other format errors, it's worth retrying.
+var ErrTruncatedResponse = fmt.Errorf("%w: truncated response", ErrUnexpectedFormat)
+
 // Client is used to interact with the European Central Bank's exchange rate service.
 // It holds an HTTP client configured for making requests.
 type Client struct {
 	httpClient *http.Client
 	ratesURL   string // URL for fetching exchange rates, allowing for easier testing.
+	historyURL string // URL for fetching the last 90 days of exchange rates, allowing for easier testing.
+	// fullHistoryURL is the URL of the feed holding every day since 1999, allowing for easier testing.
+	fullHistoryURL string
+	// fullHistory tells whether FetchExchangeRateOn reads the full historical feed instead of the 90-day one.
+	fullHistory bool
+	// fallbackDays is how many days FetchExchangeRateOn may walk back when the requested day has no rates.
+	fallbackDays int
+	// now returns the current time. All the date and expiry logic of the client relies on it.
+	now func() time.Time
+	// cache keeps the feeds' contents for a while, it's nil when caching is disabled.
+	cache *cache
+	// maxAge is how old the latest rates can be before onStale is called. It's only used if onStale is set.
+	maxAge time.Duration
+	// onStale is called with the age of the latest rates when they're older than maxAge. It can be nil.
+	onStale func(age time.Duration)
+	// pinnedDate is the day the client reads the rates of, instead of the latest ones. Zero means not pinned.
+	pinnedDate time.Time
+	// aliases maps alternative currency codes, e.g. legacy ones, to the codes used in the feed. It can be nil.
+	aliases map[string]string
+	// headers are set on every request to the ECB, a User-Agent naming this library by default.
+	headers http.Header
+}
+
+// defaultUserAgent is the User-Agent header sent to the ECB, unless WithHeaders sets another one.
+// Go's default, "Go-http-client/1.1", is rejected by some servers and proxies.
+const defaultUserAgent = "learning-go-ecbank/1.0 (+https://github.com/seulchan/learning-go)"
+
+// defaultTimeout is the timeout of the client returned by DefaultClient.
+// It leaves the ECB plenty of time to answer, without hanging forever if it doesn't.
+const defaultTimeout = 10 * time.Second
+
+// DefaultClient returns an ECB Client ready to fetch the official feeds, with a 10-second timeout.
+// It suits quick scripts; use NewClient to choose the timeout or the options.
+func DefaultClient() Client {
+	return NewClient(defaultTimeout)
 }
 
 // NewClient creates and returns a new ECB Client.
 // It takes a timeout duration, which is applied to HTTP requests made by the client.
-func NewClient(timeout time.Duration) Client {
-	return Client{
+// Beware that a timeout of 0 means no timeout at all: DefaultClient is a safer start.
+// It also takes a list of configuration functions to tune it at your will.
+func NewClient(timeout time.Duration, opts ...Option) Client {
+	c := Client{
 		httpClient: &http.Client{Timeout: timeout},
 		// This is the official daily Euro foreign exchange reference rates XML feed.
 		ratesURL: "http://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml",
+		// This feed contains the reference rates of the last 90 days, most recent day first.
+		historyURL: "http://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist-90d.xml",
+		// This feed contains the reference rates of every day since 1999, most recent day first.
+		fullHistoryURL: "http://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist.xml",
+		now:            time.Now,
+		headers:        http.Header{"User-Agent": {defaultUserAgent}},
+	}
+
+	for _, configFunc := range opts {
+		configFunc(&c)
 	}
+
+	return c
 }
 
 // FetchExchangeRate fetches today's ExchangeRate and returns it.
 // It communicates with the ECB service, parses the response, and calculates the rate.
+// If the client was created with WithPinnedDate, it returns the rate of the pinned day instead,
+// as FetchExchangeRateOn would.
 func (c Client) FetchExchangeRate(source, target money.Currency) (money.ExchangeRate, error) {
-	// Make an HTTP GET request to the ECB's rates URL.
-	resp, err := c.httpClient.Get(c.ratesURL)
+	env, err := c.FetchEnvelope()
+	if err != nil {
+		return money.ExchangeRate{}, err
+	}
+
+	// If everything is successful, return the fetched rate.
+	return env.FetchExchangeRate(source, target)
+}
+
+// Rate is an exchange rate, with how it was obtained.
+type Rate struct {
+	// Value is the rate itself.
+	Value money.ExchangeRate
+	// SameCurrency tells that the source and target currencies are the same: Value is 1 by definition,
+	// not because the market says so, and a UI may not bother displaying it.
+	SameCurrency bool
+}
+
+// FetchRate is like FetchExchangeRate, but it tells whether the rate is the trivial 1 between a currency and itself,
+// or a genuine rate that happens to be 1. The feed isn't fetched at all for the same currency.
+// Currencies are compared once their aliases are resolved, see WithCurrencyAliases.
+func (c Client) FetchRate(source, target money.Currency) (Rate, error) {
+	if resolveAlias(c.aliases, source.Code()) == resolveAlias(c.aliases, target.Code()) {
+		one, err := money.ParseDecimal("1")
+		if err != nil {
+			return Rate{}, fmt.Errorf("unable to create a rate of value 1: %w", err)
+		}
+		return Rate{Value: money.ExchangeRate(one), SameCurrency: true}, nil
+	}
+
+	rate, err := c.FetchExchangeRate(source, target)
+	if err != nil {
+		return Rate{}, err
+	}
+	return Rate{Value: rate}, nil
+}
+
+// FetchEnvelope fetches today's rates, all at once, for callers that need more than a single pair:
+// the day they were published, and the rate of every currency in the feed.
+// The Envelope can then compute as many rates as needed without downloading the feed again.
+// If the client was created with WithPinnedDate, it holds the rates of the pinned day instead.
+func (c Client) FetchEnvelope() (Envelope, error) {
+	rates, err := c.currentRates()
+	if err != nil {
+		return Envelope{}, err
+	}
+
+	env := newEnvelope(rates)
+	env.aliases = c.aliases
+	return env, nil
+}
+
+// currentRates returns the rates used by the methods that don't take a day: the latest ones,
+// or those of the pinned day if the client was created with WithPinnedDate.
+// Every such method goes through FetchEnvelope, so the day is chosen here, and only here.
+func (c Client) currentRates() (dailyRates, error) {
+	if !c.pinnedDate.IsZero() {
+		// The pinned day is old on purpose: there's no point in warning that its rates are stale.
+		rates, _, err := c.ratesOn(c.pinnedDate)
+		return rates, err
+	}
+
+	body, err := c.fetch(context.Background(), c.ratesURL)
+	if err != nil {
+		return dailyRates{}, err
+	}
+
+	xrefMessage, err := decodeEnvelope(bytes.NewReader(body))
+	if err != nil {
+		return dailyRates{}, err
+	}
+	c.warnIfStale(xrefMessage.latest())
+
+	return xrefMessage.latest(), nil
+}
+
+// FetchExchangeRatePair fetches today's ExchangeRates between two currencies, in both directions:
+// ab converts from a to b, and ba from b to a. The feed is fetched and parsed only once.
+// Both rates are computed with exact fractions, like FetchExchangeRate's, so they're reciprocals
+// up to the 9 decimal places kept for cross rates.
+// If the client was created with WithPinnedDate, both rates are those of the pinned day.
+func (c Client) FetchExchangeRatePair(a, b money.Currency) (ab, ba money.ExchangeRate, err error) {
+	env, err := c.FetchEnvelope()
+	if err != nil {
+		return money.ExchangeRate{}, money.ExchangeRate{}, err
+	}
+
+	ab, err = env.ExchangeRate(a, b)
+	if err != nil {
+		return money.ExchangeRate{}, money.ExchangeRate{}, err
+	}
+	ba, err = env.ExchangeRate(b, a)
+	if err != nil {
+		return money.ExchangeRate{}, money.ExchangeRate{}, err
+	}
+	return ab, ba, nil
+}
+
+// FetchExchangeRateOn fetches the ExchangeRate published on the given day.
+// A zero day means today, according to the client's clock.
+// If the client was created with WithFallbackToPreviousDay, a day without rates makes it
+// look at the previous days instead. It returns the rate and the day it was published on.
+// Only the last 90 days are available, unless the client was created with WithFullHistory.
+func (c Client) FetchExchangeRateOn(source, target money.Currency, day time.Time) (money.ExchangeRate, time.Time, error) {
+	if day.IsZero() {
+		day = c.today()
+	}
+
+	rates, used, err := c.ratesOn(day)
+	if err != nil {
+		return money.ExchangeRate{}, time.Time{}, err
+	}
+
+	rate, err := rates.exchangeRate(resolveAlias(c.aliases, source.Code()), resolveAlias(c.aliases, target.Code()))
+	if err != nil {
+		return money.ExchangeRate{}, time.Time{}, err
+	}
+	return rate, used, nil
+}
+
+// ratesOn returns the rates published on the given day, or on one of the previous days allowed by
+// WithFallbackToPreviousDay, and the day they were published on. It reads the 90-day feed,
+// or the full historical one if the client was created with WithFullHistory.
+func (c Client) ratesOn(day time.Time) (dailyRates, time.Time, error) {
+	if c.fullHistory {
+		// The full feed is large: it's streamed straight from the response, and never cached.
+		resp, err := c.get(context.Background(), c.fullHistoryURL)
+		if err != nil {
+			return dailyRates{}, time.Time{}, err
+		}
+		defer resp.Body.Close()
+
+		return readDayFromResponse(day, c.fallbackDays, resp.Body)
+	}
+
+	body, err := c.fetch(context.Background(), c.historyURL)
+	if err != nil {
+		return dailyRates{}, time.Time{}, err
+	}
+
+	return readDayFromResponse(day, c.fallbackDays, bytes.NewReader(body))
+}
+
+// ConvertToMany converts an amount into each of the target currencies, fetching the rates only once.
+// It returns the converted amounts keyed by target currency code.
+// A target that can't be converted (e.g. a currency missing from the feed) doesn't stop the batch:
+// it's left out of the map, and its error is joined to the returned error.
+// If the feed itself can't be fetched, the map is nil.
+func (c Client) ConvertToMany(amount money.Amount, targets []money.Currency) (map[string]money.Amount, error) {
+	env, err := c.FetchEnvelope()
+	if err != nil {
+		return nil, err
+	}
+
+	converted := make(map[string]money.Amount, len(targets))
+	var errs []error
+	for _, target := range targets {
+		// The envelope provides the rates, so that money.Convert doesn't fetch the feed again for each target.
+		result, err := money.Convert(amount, target, env)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("converting to %s: %w", target.Code(), err))
+			continue
+		}
+		converted[target.Code()] = result
+	}
+
+	// errors.Join returns nil when there are no errors.
+	return converted, errors.Join(errs...)
+}
+
+// warnIfStale calls the client's onStale callback if the given rates are older than maxAge.
+// The age is counted from midnight UTC of the day the rates were published, according to the client's clock.
+// Rates without a valid publication day can't be dated, and don't trigger the callback.
+func (c Client) warnIfStale(rates dailyRates) {
+	if c.onStale == nil {
+		return
+	}
+
+	published, err := time.Parse(dayLayout, rates.Time)
+	if err != nil {
+		return
+	}
+
+	if age := c.now().Sub(published); age > c.maxAge {
+		c.onStale(age)
+	}
+}
+
+// today returns the current day, at midnight in UTC.
+func (c Client) today() time.Time {
+	year, month, day := c.now().UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// HealthCheck checks that the ECB feed is reachable and can be parsed, without looking up any rate.
+// It's meant for readiness probes. The returned errors are the same as FetchExchangeRate's.
+func (c Client) HealthCheck(ctx context.Context) error {
+	resp, err := c.get(ctx, c.ratesURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	xrefMessage, err := decodeEnvelope(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if len(xrefMessage.Days) == 0 {
+		return fmt.Errorf("%w: no rates in the feed", ErrUnexpectedFormat)
+	}
+
+	return nil
+}
+
+// fetch returns the contents of the feed at the given URL.
+// If caching is enabled, a feed fetched recently enough is served from the cache.
+func (c Client) fetch(ctx context.Context, rawURL string) ([]byte, error) {
+	if body, found := c.cache.get(rawURL, c.now()); found {
+		return body, nil
+	}
+
+	resp, err := c.get(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	// defer ensures that resp.Body.Close() is called just before the fetch function returns.
+	// This is crucial for releasing resources and preventing memory leaks.
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		// The server announced a longer response than what was received: the connection dropped.
+		return nil, fmt.Errorf("%w: unable to read the response: %v", ErrTruncatedResponse, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to read the response: %v", ErrCallingServer, err)
+	}
+
+	c.cache.put(rawURL, body, c.now())
+	return body, nil
+}
+
+// get makes an HTTP GET request to the given URL and checks the response's status code.
+// On success, the caller is responsible for closing the response body.
+func (c Client) get(ctx context.Context, rawURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCallingServer, err)
+	}
+	for key, values := range c.headers {
+		req.Header[key] = values
+	}
+
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		// Check if the error is a URL error (e.g., network issue, DNS problem).
 		var urlErr *url.Error
@@ -59,27 +374,20 @@ func (c Client) FetchExchangeRate(source, target money.Currency) (money.Exchange
 		if errors.As(err, &urlErr) && urlErr.Timeout() {
 			// If the error is specifically a timeout, wrap it with our custom ErrTimeout.
 			// Wrapping (using %w) preserves the original error for further inspection if needed.
-			return money.ExchangeRate{}, fmt.Errorf("%w: %v", ErrTimeout, urlErr)
+			return nil, fmt.Errorf("%w: %v", ErrTimeout, urlErr)
 		}
 		// For other types of errors during the GET request, wrap them with ErrCallingServer.
-		return money.ExchangeRate{}, fmt.Errorf("%w: %v", ErrCallingServer, err)
+		return nil, fmt.Errorf("%w: %v", ErrCallingServer, err)
 	}
-	// defer ensures that resp.Body.Close() is called just before the FetchExchangeRate function returns.
-	// This is crucial for releasing resources and preventing memory leaks.
-	defer resp.Body.Close()
 
 	// Check the HTTP status code of the response.
 	if err = checkStatusCode(resp.StatusCode); err != nil {
 		// If the status code indicates an error (e.g., 404 Not Found, 500 Server Error), return the error.
-		return money.ExchangeRate{}, err
+		resp.Body.Close()
+		return nil, err
 	}
 
-	rate, err := readRateFromResponse(source.Code(), target.Code(), resp.Body)
-	if err != nil {
-		return money.ExchangeRate{}, err
-	}
-	// If everything is successful, return the fetched rate.
-	return rate, nil
+	return resp, nil
 }
 
 // checkStatusCode examines the HTTP status code and returns a specific error if the code indicates a problem.
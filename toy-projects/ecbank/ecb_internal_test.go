@@ -1,15 +1,32 @@
 package ecbank
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	money "learning-go/moneyconverter"
+	"math"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"slices"
+	"sync/atomic"
 	"testing"
 	"time"
 )
 
+func TestDefaultClient(t *testing.T) {
+	ecb := DefaultClient()
+
+	if ecb.httpClient.Timeout == 0 {
+		t.Error("expected a timeout, got none")
+	}
+	if ecb.ratesURL != "http://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml" {
+		t.Errorf("expected the official rates URL, got %q", ecb.ratesURL)
+	}
+}
+
 func TestEuroCentralBank_FetchExchangeRate_Success(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprintln(w, `<?xml version="1.0" encoding="UTF-8"?><gesmes:Envelope><Cube><Cube>
@@ -37,6 +54,313 @@ func TestEuroCentralBank_FetchExchangeRate_Success(t *testing.T) {
 	}
 }
 
+func TestEuroCentralBank_FetchExchangeRatePair(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprintln(w, `<?xml version="1.0" encoding="UTF-8"?><gesmes:Envelope><Cube><Cube>
+			<Cube currency='USD' rate='1.0876'/>
+			<Cube currency='RON' rate='4.9713'/>
+		</Cube></Cube></gesmes:Envelope>`)
+	}))
+	defer ts.Close()
+
+	ecb := NewClient(time.Second)
+	ecb.ratesURL = ts.URL
+
+	ab, ba, err := ecb.FetchExchangeRatePair(mustParseCurrency(t, "USD"), mustParseCurrency(t, "RON"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected the feed to be fetched once, got %d calls", calls)
+	}
+
+	// 4.9713 / 1.0876 and 1.0876 / 4.9713, rounded to 9 decimal places.
+	if want := money.ExchangeRate(mustParseDecimal(t, "4.570890033")); ab != want {
+		t.Errorf("FetchExchangeRatePair() ab = %v, want %v", ab, want)
+	}
+	if want := money.ExchangeRate(mustParseDecimal(t, "0.218775773")); ba != want {
+		t.Errorf("FetchExchangeRatePair() ba = %v, want %v", ba, want)
+	}
+
+	// Both rates are rounded to 9 decimal places: their product is 1, give or take a few billionths.
+	abDecimal, baDecimal := money.Decimal(ab), money.Decimal(ba)
+	abRat, _ := new(big.Rat).SetString(abDecimal.String())
+	baRat, _ := new(big.Rat).SetString(baDecimal.String())
+	product, _ := new(big.Rat).Mul(abRat, baRat).Float64()
+	if math.Abs(product-1) > 1e-8 {
+		t.Errorf("expected reciprocal rates, got %v × %v = %v", ab, ba, product)
+	}
+
+	if _, _, err := ecb.FetchExchangeRatePair(mustParseCurrency(t, "USD"), mustParseCurrency(t, "JPY")); !errors.Is(err, ErrExchangeRateNotFound) {
+		t.Errorf("expected error %v, got %v", ErrExchangeRateNotFound, err)
+	}
+}
+
+func TestEuroCentralBank_FetchExchangeRatePair_WithPinnedDate(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/daily":
+			fmt.Fprintln(w, `<?xml version="1.0" encoding="UTF-8"?><gesmes:Envelope><Cube>
+				<Cube time='2023-10-30'><Cube currency='USD' rate='1.5'/></Cube>
+			</Cube></gesmes:Envelope>`)
+		case "/history":
+			fmt.Fprintln(w, `<?xml version="1.0" encoding="UTF-8"?><gesmes:Envelope><Cube>
+				<Cube time='2023-10-30'><Cube currency='USD' rate='1.5'/></Cube>
+				<Cube time='2023-10-27'><Cube currency='USD' rate='1.1'/></Cube>
+			</Cube></gesmes:Envelope>`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	ecb := NewClient(time.Second, WithPinnedDate(mustParseDay(t, "2023-10-27")))
+	ecb.ratesURL = ts.URL + "/daily"
+	ecb.historyURL = ts.URL + "/history"
+
+	ab, ba, err := ecb.FetchExchangeRatePair(mustParseCurrency(t, "EUR"), mustParseCurrency(t, "USD"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The pinned day's rate is used, not the latest one.
+	if want := money.ExchangeRate(mustParseDecimal(t, "1.1")); ab != want {
+		t.Errorf("FetchExchangeRatePair() ab = %v, want %v", ab, want)
+	}
+	// 1 / 1.1, rounded to 9 decimal places.
+	if want := money.ExchangeRate(mustParseDecimal(t, "0.909090909")); ba != want {
+		t.Errorf("FetchExchangeRatePair() ba = %v, want %v", ba, want)
+	}
+}
+
+func TestEuroCentralBank_FetchEnvelope(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `<?xml version="1.0" encoding="UTF-8"?><gesmes:Envelope><Cube>
+			<Cube time='2023-10-27'>
+				<Cube currency='USD' rate='1.0876'/>
+				<Cube currency='RON' rate='4.9713'/>
+				<Cube currency='XXX' rate='not a number'/>
+			</Cube>
+		</Cube></gesmes:Envelope>`)
+	}))
+	defer ts.Close()
+
+	ecb := NewClient(time.Second)
+	ecb.ratesURL = ts.URL
+
+	env, err := ecb.FetchEnvelope()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := mustParseDay(t, "2023-10-27"); !env.Date.Equal(want) {
+		t.Errorf("FetchEnvelope() Date = %v, want %v", env.Date, want)
+	}
+
+	wantRates := map[string]money.ExchangeRate{
+		"EUR": money.ExchangeRate(mustParseDecimal(t, "1")),
+		"USD": money.ExchangeRate(mustParseDecimal(t, "1.0876")),
+		"RON": money.ExchangeRate(mustParseDecimal(t, "4.9713")),
+	}
+	if !reflect.DeepEqual(env.Rates, wantRates) {
+		t.Errorf("FetchEnvelope() Rates = %v, want %v", env.Rates, wantRates)
+	}
+
+	// The envelope computes cross rates like FetchExchangeRate.
+	got, err := env.ExchangeRate(mustParseCurrency(t, "USD"), mustParseCurrency(t, "RON"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := money.ExchangeRate(mustParseDecimal(t, "4.570890033")); got != want {
+		t.Errorf("ExchangeRate() = %v, want %v", got, want)
+	}
+
+	if _, err := env.ExchangeRate(mustParseCurrency(t, "USD"), mustParseCurrency(t, "XXX")); !errors.Is(err, ErrInvalidRate) {
+		t.Errorf("expected error %v, got %v", ErrInvalidRate, err)
+	}
+}
+
+func TestEuroCentralBank_FetchRate(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprintln(w, `<?xml version="1.0" encoding="UTF-8"?><gesmes:Envelope><Cube><Cube>
+			<Cube currency='USD' rate='1'/>
+		</Cube></Cube></gesmes:Envelope>`)
+	}))
+	defer ts.Close()
+
+	ecb := NewClient(time.Second)
+	ecb.ratesURL = ts.URL
+	one := money.ExchangeRate(mustParseDecimal(t, "1"))
+
+	t.Run("same currency", func(t *testing.T) {
+		got, err := ecb.FetchRate(mustParseCurrency(t, "USD"), mustParseCurrency(t, "USD"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := (Rate{Value: one, SameCurrency: true}); got != want {
+			t.Errorf("FetchRate() = %v, want %v", got, want)
+		}
+		if calls != 0 {
+			t.Errorf("expected no call to the feed, got %d", calls)
+		}
+	})
+
+	t.Run("genuine rate of 1", func(t *testing.T) {
+		got, err := ecb.FetchRate(mustParseCurrency(t, "EUR"), mustParseCurrency(t, "USD"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := (Rate{Value: one, SameCurrency: false}); got != want {
+			t.Errorf("FetchRate() = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestEuroCentralBank_WithCurrencyAliases(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `<?xml version="1.0" encoding="UTF-8"?><gesmes:Envelope><Cube><Cube time='2024-03-15'>
+			<Cube currency='USD' rate='1.0876'/>
+			<Cube currency='RON' rate='4.9713'/>
+		</Cube></Cube></gesmes:Envelope>`)
+	}))
+	defer ts.Close()
+
+	// ROL is the code of the Romanian leu before its 2005 redenomination, the feed only knows RON.
+	aliases := map[string]string{"ROL": "RON"}
+	ecb := NewClient(time.Second, WithCurrencyAliases(aliases))
+	ecb.ratesURL = ts.URL
+	ecb.historyURL = ts.URL
+
+	// The client keeps its own copy of the aliases.
+	aliases["ROL"] = "USD"
+
+	t.Run("alias resolves to the canonical code", func(t *testing.T) {
+		got, err := ecb.FetchExchangeRate(mustParseCurrency(t, "EUR"), mustParseCurrency(t, "ROL"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := money.ExchangeRate(mustParseDecimal(t, "4.9713")); got != want {
+			t.Errorf("FetchExchangeRate() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("conversion through an alias", func(t *testing.T) {
+		amount, err := money.NewAmount(mustParseDecimal(t, "100"), mustParseCurrency(t, "ROL"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		converted, err := ecb.ConvertToMany(amount, []money.Currency{mustParseCurrency(t, "RON")})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := converted["RON"].String(); got != "100.00 RON" {
+			t.Errorf("expected 100.00 RON, got %s", got)
+		}
+	})
+
+	t.Run("alias and canonical code are the same currency", func(t *testing.T) {
+		got, err := ecb.FetchRate(mustParseCurrency(t, "ROL"), mustParseCurrency(t, "RON"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !got.SameCurrency {
+			t.Errorf("expected ROL and RON to be the same currency, got %v", got)
+		}
+	})
+
+	t.Run("historical rate", func(t *testing.T) {
+		day := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+		got, _, err := ecb.FetchExchangeRateOn(mustParseCurrency(t, "EUR"), mustParseCurrency(t, "ROL"), day)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := money.ExchangeRate(mustParseDecimal(t, "4.9713")); got != want {
+			t.Errorf("FetchExchangeRateOn() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("unknown code without alias", func(t *testing.T) {
+		_, err := ecb.FetchExchangeRate(mustParseCurrency(t, "EUR"), mustParseCurrency(t, "XYZ"))
+		if !errors.Is(err, ErrExchangeRateNotFound) {
+			t.Errorf("expected %v, got %v", ErrExchangeRateNotFound, err)
+		}
+	})
+}
+
+func TestEuroCentralBank_WithHeaders(t *testing.T) {
+	var received http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		fmt.Fprintln(w, `<?xml version="1.0" encoding="UTF-8"?><gesmes:Envelope><Cube><Cube>
+			<Cube currency='USD' rate='1.0876'/>
+		</Cube></Cube></gesmes:Envelope>`)
+	}))
+	defer ts.Close()
+
+	t.Run("default User-Agent", func(t *testing.T) {
+		ecb := NewClient(time.Second)
+		ecb.ratesURL = ts.URL
+
+		if _, err := ecb.FetchExchangeRate(mustParseCurrency(t, "EUR"), mustParseCurrency(t, "USD")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := received.Get("User-Agent"); got != defaultUserAgent {
+			t.Errorf("expected User-Agent %q, got %q", defaultUserAgent, got)
+		}
+	})
+
+	t.Run("custom headers", func(t *testing.T) {
+		headers := http.Header{}
+		headers.Set("User-Agent", "rates-dashboard/2.3")
+		headers.Add("X-Api-Key", "secret")
+		// Keys that aren't canonical are fixed, and still replace the default.
+		headers["accept-language"] = []string{"en", "fr"}
+
+		ecb := NewClient(time.Second, WithHeaders(headers))
+		ecb.ratesURL = ts.URL
+
+		// The client keeps its own copy of the headers.
+		headers.Set("X-Api-Key", "changed")
+
+		if err := ecb.HealthCheck(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := received.Values("User-Agent"); !slices.Equal(got, []string{"rates-dashboard/2.3"}) {
+			t.Errorf("expected User-Agent rates-dashboard/2.3, got %q", got)
+		}
+		if got := received.Get("X-Api-Key"); got != "secret" {
+			t.Errorf("expected X-Api-Key secret, got %q", got)
+		}
+		if got := received.Values("Accept-Language"); !slices.Equal(got, []string{"en", "fr"}) {
+			t.Errorf("expected Accept-Language en and fr, got %q", got)
+		}
+	})
+}
+
+func TestEuroCentralBank_FetchExchangeRate_TruncatedResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The server announces more than it sends, as if the connection dropped during the download.
+		w.Header().Set("Content-Length", "1000")
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?><gesmes:Envelope><Cube><Cube>`)
+	}))
+	defer ts.Close()
+
+	ecb := NewClient(time.Second)
+	ecb.ratesURL = ts.URL
+
+	_, err := ecb.FetchExchangeRate(mustParseCurrency(t, "EUR"), mustParseCurrency(t, "USD"))
+	if !errors.Is(err, ErrTruncatedResponse) {
+		t.Errorf("expected error %v, got %v", ErrTruncatedResponse, err)
+	}
+	if !errors.Is(err, ErrUnexpectedFormat) {
+		t.Errorf("expected error %v, got %v", ErrUnexpectedFormat, err)
+	}
+}
+
 func TestEuroCentralBank_FetchExchangeRate_Timeout(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		time.Sleep(2 * time.Second) // Sleep longer than client timeout
@@ -74,3 +398,398 @@ func mustParseDecimal(t *testing.T, decimal string) money.Decimal {
 
 	return dec
 }
+
+func TestEuroCentralBank_FetchExchangeRateOn_FallbackToPreviousDay(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `<?xml version="1.0" encoding="UTF-8"?><gesmes:Envelope><Cube>
+			<Cube time='2023-10-30'><Cube currency='USD' rate='1.5'/></Cube>
+			<Cube time='2023-10-27'><Cube currency='USD' rate='2'/><Cube currency='RON' rate='6'/></Cube>
+		</Cube></gesmes:Envelope>`)
+	}))
+	defer ts.Close()
+
+	ecb := NewClient(time.Second, WithFallbackToPreviousDay(3))
+	ecb.historyURL = ts.URL
+
+	// Sunday: the closest published day is the previous Friday.
+	got, day, err := ecb.FetchExchangeRateOn(mustParseCurrency(t, "USD"), mustParseCurrency(t, "RON"), mustParseDay(t, "2023-10-29"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := money.ExchangeRate(mustParseDecimal(t, "3"))
+	if got != want {
+		t.Errorf("FetchExchangeRateOn() got = %v, want %v", got, want)
+	}
+
+	if wantDay := mustParseDay(t, "2023-10-27"); !day.Equal(wantDay) {
+		t.Errorf("FetchExchangeRateOn() day = %v, want %v", day, wantDay)
+	}
+}
+
+// TestWithFallbackToPreviousDay_Negative tests that a negative number of days is clamped to 0, i.e. no fallback.
+func TestWithFallbackToPreviousDay_Negative(t *testing.T) {
+	ecb := NewClient(time.Second, WithFallbackToPreviousDay(-2))
+	if ecb.fallbackDays != 0 {
+		t.Errorf("expected fallbackDays to be 0, got %d", ecb.fallbackDays)
+	}
+}
+
+func TestEuroCentralBank_FetchExchangeRateOn_FullHistory(t *testing.T) {
+	feed := historicalFeed(mustParseDay(t, "2019-01-01"), mustParseDay(t, "2023-12-31"))
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, feed)
+	}))
+	defer ts.Close()
+
+	ecb := NewClient(time.Second, WithFullHistory())
+	ecb.fullHistoryURL = ts.URL
+	// The 90-day feed must not be used.
+	ecb.historyURL = "http://localhost:0"
+
+	got, day, err := ecb.FetchExchangeRateOn(mustParseCurrency(t, "EUR"), mustParseCurrency(t, "USD"), mustParseDay(t, "2019-02-03"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := money.ExchangeRate(mustParseDecimal(t, "2019.0203"))
+	if got != want {
+		t.Errorf("FetchExchangeRateOn() got = %v, want %v", got, want)
+	}
+	if wantDay := mustParseDay(t, "2019-02-03"); !day.Equal(wantDay) {
+		t.Errorf("FetchExchangeRateOn() day = %v, want %v", day, wantDay)
+	}
+}
+
+func TestEuroCentralBank_WithPinnedDate(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/daily":
+			fmt.Fprintln(w, `<?xml version="1.0" encoding="UTF-8"?><gesmes:Envelope><Cube>
+				<Cube time='2023-10-30'><Cube currency='USD' rate='1.5'/></Cube>
+			</Cube></gesmes:Envelope>`)
+		case "/history":
+			fmt.Fprintln(w, `<?xml version="1.0" encoding="UTF-8"?><gesmes:Envelope><Cube>
+				<Cube time='2023-10-30'><Cube currency='USD' rate='1.5'/></Cube>
+				<Cube time='2023-10-27'><Cube currency='USD' rate='2'/></Cube>
+			</Cube></gesmes:Envelope>`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	tt := map[string]struct {
+		day  time.Time
+		want string
+	}{
+		"pinned":     {day: mustParseDay(t, "2023-10-27"), want: "2"},
+		"not pinned": {day: time.Time{}, want: "1.5"},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			ecb := NewClient(time.Second, WithPinnedDate(tc.day))
+			ecb.ratesURL = ts.URL + "/daily"
+			ecb.historyURL = ts.URL + "/history"
+
+			got, err := ecb.FetchExchangeRate(mustParseCurrency(t, "EUR"), mustParseCurrency(t, "USD"))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			want := money.ExchangeRate(mustParseDecimal(t, tc.want))
+			if got != want {
+				t.Errorf("FetchExchangeRate() got = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestEuroCentralBank_WithStaleWarning(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `<?xml version="1.0" encoding="UTF-8"?><gesmes:Envelope><Cube>
+			<Cube time='2023-10-27'><Cube currency='USD' rate='1.5'/></Cube>
+		</Cube></gesmes:Envelope>`)
+	}))
+	defer ts.Close()
+
+	tt := map[string]struct {
+		now       time.Time
+		wantCalls []time.Duration
+	}{
+		"fresh feed": {
+			now: time.Date(2023, time.October, 27, 18, 0, 0, 0, time.UTC),
+		},
+		"feed after a weekend, within the limit": {
+			now: time.Date(2023, time.October, 29, 23, 0, 0, 0, time.UTC),
+		},
+		"stale feed": {
+			now:       time.Date(2023, time.November, 1, 12, 0, 0, 0, time.UTC),
+			wantCalls: []time.Duration{5*24*time.Hour + 12*time.Hour},
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			var calls []time.Duration
+			ecb := NewClient(time.Second,
+				WithClock(func() time.Time { return tc.now }),
+				WithStaleWarning(72*time.Hour, func(age time.Duration) { calls = append(calls, age) }),
+			)
+			ecb.ratesURL = ts.URL
+
+			if _, err := ecb.FetchExchangeRate(mustParseCurrency(t, "EUR"), mustParseCurrency(t, "USD")); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(calls, tc.wantCalls) {
+				t.Errorf("expected the callback to be called with %v, got %v", tc.wantCalls, calls)
+			}
+		})
+	}
+}
+
+func TestEuroCentralBank_WithStaleWarning_Methods(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `<?xml version="1.0" encoding="UTF-8"?><gesmes:Envelope><Cube>
+			<Cube time='2023-10-27'><Cube currency='USD' rate='1.5'/></Cube>
+		</Cube></gesmes:Envelope>`)
+	}))
+	defer ts.Close()
+
+	eur, usd := mustParseCurrency(t, "EUR"), mustParseCurrency(t, "USD")
+	stale := time.Date(2023, time.November, 1, 12, 0, 0, 0, time.UTC)
+
+	tt := map[string]struct {
+		opts      []Option
+		call      func(ecb Client) error
+		wantCalls int
+	}{
+		"pair of latest rates": {
+			call: func(ecb Client) error {
+				_, _, err := ecb.FetchExchangeRatePair(eur, usd)
+				return err
+			},
+			wantCalls: 1,
+		},
+		"pinned day": {
+			opts: []Option{WithPinnedDate(mustParseDay(t, "2023-10-27"))},
+			call: func(ecb Client) error {
+				_, err := ecb.FetchExchangeRate(eur, usd)
+				return err
+			},
+			wantCalls: 0,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			opts := append([]Option{
+				WithClock(func() time.Time { return stale }),
+				WithStaleWarning(72*time.Hour, func(time.Duration) { calls++ }),
+			}, tc.opts...)
+			ecb := NewClient(time.Second, opts...)
+			ecb.ratesURL = ts.URL
+			ecb.historyURL = ts.URL
+
+			if err := tc.call(ecb); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if calls != tc.wantCalls {
+				t.Errorf("expected the callback to be called %d times, got %d", tc.wantCalls, calls)
+			}
+		})
+	}
+}
+
+func TestEuroCentralBank_HealthCheck(t *testing.T) {
+	tt := map[string]struct {
+		status int
+		body   string
+		err    error
+	}{
+		"healthy": {
+			status: http.StatusOK,
+			body: `<?xml version="1.0" encoding="UTF-8"?><gesmes:Envelope><Cube><Cube time='2023-10-27'>
+				<Cube currency='USD' rate='2'/>
+			</Cube></Cube></gesmes:Envelope>`,
+			err: nil,
+		},
+		"server error": {
+			status: http.StatusInternalServerError,
+			err:    ErrServerSide,
+		},
+		"malformed XML": {
+			status: http.StatusOK,
+			body:   `<?xml version="1.0" encoding="UTF-8"?><MalformedXML>`,
+			err:    ErrUnexpectedFormat,
+		},
+		"no rates": {
+			status: http.StatusOK,
+			body:   `<?xml version="1.0" encoding="UTF-8"?><html></html>`,
+			err:    ErrUnexpectedFormat,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				fmt.Fprintln(w, tc.body)
+			}))
+			defer ts.Close()
+
+			ecb := NewClient(time.Second)
+			ecb.ratesURL = ts.URL
+
+			err := ecb.HealthCheck(context.Background())
+			if !errors.Is(err, tc.err) {
+				t.Errorf("unexpected error: %v, expected %v", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestEuroCentralBank_WithClock(t *testing.T) {
+	// hits is updated by the server's goroutine, and read by the test.
+	var hits atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		fmt.Fprintln(w, `<?xml version="1.0" encoding="UTF-8"?><gesmes:Envelope><Cube>
+			<Cube time='2023-10-30'><Cube currency='USD' rate='1.5'/></Cube>
+			<Cube time='2023-10-27'><Cube currency='USD' rate='2'/></Cube>
+		</Cube></gesmes:Envelope>`)
+	}))
+	defer ts.Close()
+
+	// Sunday, 29th of October 2023, in the evening.
+	now := time.Date(2023, time.October, 29, 20, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	ecb := NewClient(time.Second, WithClock(clock), WithCache(time.Hour), WithFallbackToPreviousDay(3))
+	ecb.ratesURL = ts.URL
+	ecb.historyURL = ts.URL
+
+	t.Run("today is selected with the clock", func(t *testing.T) {
+		got, day, err := ecb.FetchExchangeRateOn(mustParseCurrency(t, "EUR"), mustParseCurrency(t, "USD"), time.Time{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := money.ExchangeRate(mustParseDecimal(t, "2")); got != want {
+			t.Errorf("FetchExchangeRateOn() got = %v, want %v", got, want)
+		}
+		if wantDay := mustParseDay(t, "2023-10-27"); !day.Equal(wantDay) {
+			t.Errorf("FetchExchangeRateOn() day = %v, want %v", day, wantDay)
+		}
+	})
+
+	t.Run("cache expires with the clock", func(t *testing.T) {
+		hits.Store(0)
+
+		// Still within the hour: the feed fetched by the previous subtest is reused.
+		now = now.Add(59 * time.Minute)
+		if _, _, err := ecb.FetchExchangeRateOn(mustParseCurrency(t, "EUR"), mustParseCurrency(t, "USD"), time.Time{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := hits.Load(); got != 0 {
+			t.Errorf("expected the feed to be served from the cache, got %d call(s) to the server", got)
+		}
+
+		// The hour has passed: the feed is fetched again.
+		now = now.Add(time.Minute)
+		if _, _, err := ecb.FetchExchangeRateOn(mustParseCurrency(t, "EUR"), mustParseCurrency(t, "USD"), time.Time{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := hits.Load(); got != 1 {
+			t.Errorf("expected the expired feed to be fetched again, got %d call(s) to the server", got)
+		}
+	})
+}
+
+func TestEuroCentralBank_ConvertToMany(t *testing.T) {
+	var hits atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		fmt.Fprintln(w, `<?xml version="1.0" encoding="UTF-8"?><gesmes:Envelope><Cube><Cube>
+			<Cube currency='USD' rate='2'/>
+			<Cube currency='RON' rate='5'/>
+		</Cube></Cube></gesmes:Envelope>`)
+	}))
+	defer ts.Close()
+
+	ecb := NewClient(time.Second)
+	ecb.ratesURL = ts.URL
+
+	amount := mustNewAmount(t, "10", "EUR")
+	targets := []money.Currency{mustParseCurrency(t, "USD"), mustParseCurrency(t, "XYZ"), mustParseCurrency(t, "RON")}
+
+	got, err := ecb.ConvertToMany(amount, targets)
+
+	// The missing currency is reported, without aborting the other conversions.
+	if !errors.Is(err, ErrExchangeRateNotFound) {
+		t.Errorf("unexpected error: %v, expected %v", err, ErrExchangeRateNotFound)
+	}
+
+	want := map[string]money.Amount{
+		"USD": mustNewAmount(t, "20", "USD"),
+		"RON": mustNewAmount(t, "50", "RON"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ConvertToMany() got = %v, want %v", got, want)
+	}
+
+	if hits.Load() != 1 {
+		t.Errorf("expected the feed to be fetched once, got %d calls", hits.Load())
+	}
+}
+
+func TestEuroCentralBank_ConvertToMany_WithPinnedDate(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/daily":
+			fmt.Fprintln(w, `<?xml version="1.0" encoding="UTF-8"?><gesmes:Envelope><Cube>
+				<Cube time='2023-10-30'><Cube currency='USD' rate='1.5'/><Cube currency='RON' rate='6'/></Cube>
+			</Cube></gesmes:Envelope>`)
+		case "/history":
+			fmt.Fprintln(w, `<?xml version="1.0" encoding="UTF-8"?><gesmes:Envelope><Cube>
+				<Cube time='2023-10-30'><Cube currency='USD' rate='1.5'/><Cube currency='RON' rate='6'/></Cube>
+				<Cube time='2023-10-27'><Cube currency='USD' rate='2'/><Cube currency='RON' rate='5'/></Cube>
+			</Cube></gesmes:Envelope>`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	ecb := NewClient(time.Second, WithPinnedDate(mustParseDay(t, "2023-10-27")))
+	ecb.ratesURL = ts.URL + "/daily"
+	ecb.historyURL = ts.URL + "/history"
+
+	targets := []money.Currency{mustParseCurrency(t, "USD"), mustParseCurrency(t, "RON")}
+	got, err := ecb.ConvertToMany(mustNewAmount(t, "10", "EUR"), targets)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The pinned day's rates are used, not the latest ones.
+	want := map[string]money.Amount{
+		"USD": mustNewAmount(t, "20", "USD"),
+		"RON": mustNewAmount(t, "50", "RON"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ConvertToMany() got = %v, want %v", got, want)
+	}
+}
+
+func mustNewAmount(t *testing.T, value, code string) money.Amount {
+	t.Helper()
+
+	amount, err := money.NewAmount(mustParseDecimal(t, value), mustParseCurrency(t, code))
+	if err != nil {
+		t.Fatalf("cannot create amount %s %s", value, code)
+	}
+
+	return amount
+}
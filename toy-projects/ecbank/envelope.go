@@ -1,56 +1,264 @@
 package ecbank
 
 import (
+	"bytes"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	money "learning-go/moneyconverter"
+	"math/big"
+	"time"
 )
 
 const baseCurrencyCode = "EUR"
 
+// dayLayout is the layout of the time attribute of the ECB feed's daily cubes.
+const dayLayout = "2006-01-02"
+
+// readRateFromResponse reads the change rate from the most recent rates of a feed.
 func readRateFromResponse(source string, target string, respBody io.Reader) (money.ExchangeRate, error) {
 	// read the response
-	decoder := xml.NewDecoder(respBody)
+	xrefMessage, err := decodeEnvelope(respBody)
+	if err != nil {
+		return money.ExchangeRate{}, err
+	}
 
-	var xrefMessage envelope
-	err := decoder.Decode(&xrefMessage)
+	return xrefMessage.exchangeRate(source, target)
+}
+
+// readRateOnFromResponse reads the rate published on the given day from a multi-day feed.
+// If that day is missing, it looks at up to fallbackDays previous days, and returns the day it used.
+func readRateOnFromResponse(source, target string, day time.Time, fallbackDays int, respBody io.Reader) (money.ExchangeRate, time.Time, error) {
+	rates, used, err := readDayFromResponse(day, fallbackDays, respBody)
 	if err != nil {
-		return money.ExchangeRate{}, fmt.Errorf("%w: %s", ErrUnexpectedFormat, err)
+		return money.ExchangeRate{}, time.Time{}, err
+	}
+
+	rate, err := rates.exchangeRate(source, target)
+	if err != nil {
+		return money.ExchangeRate{}, time.Time{}, err
+	}
+	return rate, used, nil
+}
+
+// readDayFromResponse reads the rates published on the given day from a multi-day feed.
+// If that day is missing, it looks at up to fallbackDays previous days, and returns the day it used.
+// The feed is streamed: only the days that may be used are decoded.
+func readDayFromResponse(day time.Time, fallbackDays int, respBody io.Reader) (dailyRates, time.Time, error) {
+	oldest := day.AddDate(0, 0, -fallbackDays)
+	xrefMessage, err := streamDays(respBody, oldest.Format(dayLayout), day.Format(dayLayout))
+	if err != nil {
+		return dailyRates{}, time.Time{}, err
+	}
+
+	for i := 0; i <= fallbackDays; i++ {
+		candidate := day.AddDate(0, 0, -i)
+
+		if rates, found := xrefMessage.on(candidate); found {
+			return rates, candidate, nil
+		}
+	}
+
+	return dailyRates{}, time.Time{}, fmt.Errorf("%w: no rates published between %s and %s",
+		ErrExchangeRateNotFound, oldest.Format(dayLayout), day.Format(dayLayout))
+}
+
+// snippetRadius is the number of bytes kept on each side of the position of a parse error in ParseError's snippet.
+const snippetRadius = 40
+
+// ParseError is returned when the ECB's response can't be decoded.
+// It matches ErrUnexpectedFormat with errors.Is, and unwraps to the underlying decoding error.
+// If the response stops before its end, it matches ErrTruncatedResponse too.
+type ParseError struct {
+	// Snippet is the part of the response around the position where decoding failed.
+	// It's empty when the response was streamed rather than read as a whole.
+	Snippet string
+	// Err is the underlying decoding error, e.g. an *xml.SyntaxError.
+	Err error
+}
+
+// Error implements the error interface for ParseError.
+// The snippet is only shown when there is one.
+func (e *ParseError) Error() string {
+	if e.Snippet == "" {
+		return fmt.Sprintf("%s: %s", ErrUnexpectedFormat, e.Err)
 	}
+	return fmt.Sprintf("%s: %s near %q", ErrUnexpectedFormat, e.Err, e.Snippet)
+}
+
+// Unwrap returns the underlying decoding error, so that errors.Unwrap and errors.As can reach it.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
 
-	rate, err := xrefMessage.exchangeRate(source, target)
+// Is makes errors.Is(err, ErrUnexpectedFormat) true for a ParseError,
+// and errors.Is(err, ErrTruncatedResponse) true for a ParseError caused by a truncated response.
+func (e *ParseError) Is(target error) bool {
+	return target == ErrUnexpectedFormat || (target == ErrTruncatedResponse && e.truncated())
+}
+
+// truncated tells whether decoding failed because the response stopped too early.
+// The XML decoder reports an end of input in the middle of an element as a syntax error.
+// A reader whose connection dropped never gets here: see readError.
+func (e *ParseError) truncated() bool {
+	var syntaxErr *xml.SyntaxError
+	return errors.As(e.Err, &syntaxErr) && syntaxErr.Msg == "unexpected EOF"
+}
+
+// readError reports a failure to read the response. It isn't the payload's fault, so it isn't a ParseError:
+// a dropped connection is reported as ErrTruncatedResponse, like in fetch, and any other failure as ErrCallingServer.
+func readError(err error) error {
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("%w: unable to read the response: %v", ErrTruncatedResponse, err)
+	}
+	return fmt.Errorf("%w: unable to read the response: %v", ErrCallingServer, err)
+}
+
+// decodeEnvelope reads the whole response and decodes it.
+// The response is kept in memory so that a ParseError can show where decoding failed.
+func decodeEnvelope(respBody io.Reader) (envelope, error) {
+	data, err := io.ReadAll(respBody)
 	if err != nil {
-		return money.ExchangeRate{}, fmt.Errorf("%w: %s", ErrExchangeRateNotFound, err)
+		return envelope{}, readError(err)
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	var xrefMessage envelope
+	if err = decoder.Decode(&xrefMessage); err != nil {
+		return envelope{}, &ParseError{Snippet: snippet(data, decoder.InputOffset()), Err: err}
+	}
+
+	return xrefMessage, nil
+}
+
+// snippet returns the bytes of data around the given offset.
+func snippet(data []byte, offset int64) string {
+	start := max(offset-snippetRadius, 0)
+	end := min(offset+snippetRadius, int64(len(data)))
+	return string(data[start:end])
+}
+
+// Envelope holds the rates the ECB published on a day, as returned by Client.FetchEnvelope.
+type Envelope struct {
+	// Date is the day the rates were published, at midnight UTC. It's zero if the feed doesn't say.
+	Date time.Time
+	// Rates maps currency codes to their rate from EUR, as published by the ECB, EUR itself included.
+	// Currencies listed in the feed with an invalid rate are left out.
+	Rates map[string]money.ExchangeRate
+	// day keeps the rates as they were written in the feed, to compute cross rates exactly.
+	day dailyRates
+	// aliases maps alternative currency codes to the codes of the feed, see WithCurrencyAliases. It can be nil.
+	aliases map[string]string
+}
+
+// newEnvelope builds the Envelope of a day's rates.
+func newEnvelope(day dailyRates) Envelope {
+	env := Envelope{
+		Rates: make(map[string]money.ExchangeRate, len(day.Rates)+1),
+		day:   day,
+	}
+
+	if date, err := time.Parse(dayLayout, day.Time); err == nil {
+		env.Date = date
 	}
-	return rate, nil
+
+	for code := range day.exchangeRates() {
+		if rate, err := day.exchangeRate(baseCurrencyCode, code); err == nil {
+			env.Rates[code] = rate
+		}
+	}
+	return env
+}
+
+// ExchangeRate returns the rate to convert from source to target, computed like Client.FetchExchangeRate's.
+// It returns an error wrapping ErrExchangeRateNotFound if a currency isn't in the feed,
+// and ErrInvalidRate if a currency's rate is invalid.
+// Currency aliases, see WithCurrencyAliases, are resolved before the rates are looked up.
+func (e Envelope) ExchangeRate(source, target money.Currency) (money.ExchangeRate, error) {
+	return e.day.exchangeRate(resolveAlias(e.aliases, source.Code()), resolveAlias(e.aliases, target.Code()))
 }
 
+// resolveAlias returns the code of the feed that code stands for, or code itself if it isn't an alias.
+// Reading a nil map is fine in Go: without aliases, every code is returned as is.
+func resolveAlias(aliases map[string]string, code string) string {
+	if canonical, ok := aliases[code]; ok {
+		return canonical
+	}
+	return code
+}
+
+// FetchExchangeRate is the same as ExchangeRate. It makes an Envelope usable by money.Convert,
+// to convert amounts without fetching the feed again.
+func (e Envelope) FetchExchangeRate(source, target money.Currency) (money.ExchangeRate, error) {
+	return e.ExchangeRate(source, target)
+}
+
+// envelope is the root of the ECB feed. It holds one cube per published day, most recent day first.
 type envelope struct {
-	Rates []currencyRate `xml:"Cube>Cube>Cube"`
+	Days []dailyRates `xml:"Cube>Cube"`
+}
+
+// dailyRates holds the rates published on a given day.
+type dailyRates struct {
+	Time  string         `xml:"time,attr"`
+	Rates []currencyRate `xml:"Cube"`
 }
 
 type currencyRate struct {
-	Currency string  `xml:"currency,attr"`
-	Rate     float64 `xml:"rate,attr"`
+	Currency string `xml:"currency,attr"`
+	// Rate is kept as it's written in the feed, e.g. "1.337": decoding it into a float64
+	// would already lose precision before it reaches money.ParseDecimal.
+	Rate string `xml:"rate,attr"`
+}
+
+// latest returns the most recently published rates of the envelope.
+func (e envelope) latest() dailyRates {
+	if len(e.Days) == 0 {
+		return dailyRates{}
+	}
+	return e.Days[0]
 }
 
-// exchangeRates builds a map of all the supported exchange rates.
-func (e envelope) exchangeRates() map[string]float64 {
-	rates := make(map[string]float64, len(e.Rates)+1)
+// on returns the rates published on the given day, and whether there are any.
+func (e envelope) on(day time.Time) (dailyRates, bool) {
+	for _, d := range e.Days {
+		if d.Time == day.Format(dayLayout) {
+			return d, true
+		}
+	}
+	return dailyRates{}, false
+}
+
+// exchangeRate reads the change rate from the Envelope's most recent rates.
+func (e envelope) exchangeRate(source, target string) (money.ExchangeRate, error) {
+	return e.latest().exchangeRate(source, target)
+}
 
-	for _, c := range e.Rates {
+// exchangeRates builds a map of all the supported exchange rates, as written in the feed.
+func (d dailyRates) exchangeRates() map[string]string {
+	rates := make(map[string]string, len(d.Rates)+1)
+
+	for _, c := range d.Rates {
 		rates[c.Currency] = c.Rate
 	}
 
 	// add EUR to EUR rate
-	rates[baseCurrencyCode] = 1.
+	rates[baseCurrencyCode] = "1"
 
 	return rates
 }
 
-// exchangeRate reads the change rate from the Envelope's contents.
-func (e envelope) exchangeRate(source, target string) (money.ExchangeRate, error) {
+// crossRatePrecision is the number of decimal places kept when a rate has to be computed by dividing two rates.
+const crossRatePrecision = 9
+
+// exchangeRate reads the change rate from the day's rates.
+// The feed gives rates from EUR: they're used as-is when EUR is the source currency.
+// Other rates are computed as the ratio of two rates, with exact fractions, and rounded to crossRatePrecision.
+// It returns an error wrapping ErrExchangeRateNotFound if a currency isn't in the feed,
+// and ErrInvalidRate if a currency's rate is missing, zero, negative or not a number.
+func (d dailyRates) exchangeRate(source, target string) (money.ExchangeRate, error) {
 	if source == target {
 		// No change rate for same source and target currencies.
 		one, err := money.ParseDecimal("1")
@@ -60,22 +268,53 @@ func (e envelope) exchangeRate(source, target string) (money.ExchangeRate, error
 		return money.ExchangeRate(one), nil
 	}
 
-	// rates stores the rates when Envelope is parsed.
-	rates := e.exchangeRates()
+	// rates stores the rates of the day when Envelope is parsed.
+	rates := d.exchangeRates()
 
 	sourceFactor, sourceFound := rates[source]
 	if !sourceFound {
-		return money.ExchangeRate{}, fmt.Errorf("failed to find the source currency %s", source)
+		return money.ExchangeRate{}, fmt.Errorf("%w: failed to find the source currency %s", ErrExchangeRateNotFound, source)
 	}
 
 	targetFactor, targetFound := rates[target]
 	if !targetFound {
-		return money.ExchangeRate{}, fmt.Errorf("failed to find target currency %s", target)
+		return money.ExchangeRate{}, fmt.Errorf("%w: failed to find target currency %s", ErrExchangeRateNotFound, target)
 	}
-	rate, err := money.ParseDecimal(fmt.Sprintf("%.9f", targetFactor/sourceFactor))
+
+	// A zero rate would make the division below fail, and a negative one would make no sense:
+	// both are checked before any computation.
+	sourceRat, err := parseRate(source, sourceFactor)
 	if err != nil {
-		return money.ExchangeRate{}, fmt.Errorf("unable to parse exchange rate from %s to %s: %w", source, target, err)
+		return money.ExchangeRate{}, err
+	}
+	targetRat, err := parseRate(target, targetFactor)
+	if err != nil {
+		return money.ExchangeRate{}, err
+	}
+
+	rateString := targetFactor
+	if source != baseCurrencyCode {
+		rateString = new(big.Rat).Quo(targetRat, sourceRat).FloatString(crossRatePrecision)
+	}
+
+	rate, err := money.ParseDecimal(rateString)
+	if err != nil {
+		return money.ExchangeRate{}, fmt.Errorf("%w: unable to parse exchange rate from %s to %s: %s", ErrExchangeRateNotFound, source, target, err)
 	}
 
 	return money.ExchangeRate(rate), nil
 }
+
+// parseRate reads a currency's rate, as written in the feed, as an exact fraction.
+// big.Rat holds exact fractions, so that no precision is lost before the final rounding of cross rates.
+// It returns ErrInvalidRate if the rate is missing, isn't a number, or isn't positive.
+func parseRate(currency, rate string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(rate)
+	if !ok {
+		return nil, fmt.Errorf("%w %q for %s", ErrInvalidRate, rate, currency)
+	}
+	if r.Sign() <= 0 {
+		return nil, fmt.Errorf("%w %q for %s: must be positive", ErrInvalidRate, rate, currency)
+	}
+	return r, nil
+}
@@ -2,10 +2,14 @@
 package ecbank
 
 import (
+	"encoding/xml"
 	"errors"
+	"io"
 	money "learning-go/moneyconverter"
 	"strings"
 	"testing"
+	"testing/iotest"
+	"time"
 )
 
 // TestReadRateFromResponse tests the entire process of reading and parsing rates from an XML response.
@@ -35,6 +39,43 @@ func TestReadRateFromResponse(t *testing.T) {
 		}
 	})
 
+	t.Run("Rate not representable as a float64 is kept exact", func(t *testing.T) {
+		xmlData := `<?xml version="1.0" encoding="UTF-8"?><gesmes:Envelope><Cube><Cube>
+			<Cube currency='USD' rate='1.337'/>
+		</Cube></Cube></gesmes:Envelope>`
+		reader := strings.NewReader(xmlData)
+
+		// 1.337 has no exact float64 representation: it must reach the Decimal without going through one.
+		expectedRate := money.ExchangeRate(mustParseDecimal(t, "1.337"))
+		rate, err := readRateFromResponse("EUR", "USD", reader)
+
+		if err != nil {
+			t.Fatalf("readRateFromResponse failed: %v", err)
+		}
+		if rate != expectedRate {
+			t.Errorf("expected rate %v, got %v", expectedRate, rate)
+		}
+	})
+
+	t.Run("Cross rate is rounded from the exact ratio", func(t *testing.T) {
+		xmlData := `<?xml version="1.0" encoding="UTF-8"?><gesmes:Envelope><Cube><Cube>
+			<Cube currency='USD' rate='1.1'/>
+			<Cube currency='RON' rate='1.3'/>
+		</Cube></Cube></gesmes:Envelope>`
+		reader := strings.NewReader(xmlData)
+
+		// 1.3 / 1.1 = 1.181818181818...
+		expectedRate := money.ExchangeRate(mustParseDecimal(t, "1.181818182"))
+		rate, err := readRateFromResponse("USD", "RON", reader)
+
+		if err != nil {
+			t.Fatalf("readRateFromResponse failed: %v", err)
+		}
+		if rate != expectedRate {
+			t.Errorf("expected rate %v, got %v", expectedRate, rate)
+		}
+	})
+
 	t.Run("Source currency not found", func(t *testing.T) {
 		xmlData := `<?xml version="1.0" encoding="UTF-8"?><gesmes:Envelope><Cube><Cube>
 			<Cube currency='USD' rate='1.25'/>
@@ -59,6 +100,33 @@ func TestReadRateFromResponse(t *testing.T) {
 		}
 	})
 
+	t.Run("Invalid rates", func(t *testing.T) {
+		xmlData := `<?xml version="1.0" encoding="UTF-8"?><gesmes:Envelope><Cube><Cube>
+			<Cube currency='USD' rate='1.25'/>
+			<Cube currency='RON' rate='0'/>
+			<Cube currency='JPY'/>
+			<Cube currency='GBP' rate='-0.8'/>
+			<Cube currency='CHF' rate='n/a'/>
+		</Cube></Cube></gesmes:Envelope>`
+
+		tt := map[string]struct{ source, target string }{
+			"zero target rate":         {source: "EUR", target: "RON"},
+			"zero source rate":         {source: "RON", target: "USD"},
+			"missing rate":             {source: "USD", target: "JPY"},
+			"negative rate":            {source: "EUR", target: "GBP"},
+			"rate that isn't a number": {source: "CHF", target: "USD"},
+		}
+
+		for name, tc := range tt {
+			t.Run(name, func(t *testing.T) {
+				_, err := readRateFromResponse(tc.source, tc.target, strings.NewReader(xmlData))
+				if !errors.Is(err, ErrInvalidRate) || !errors.Is(err, ErrUnexpectedFormat) {
+					t.Errorf("expected error %v, got %v", ErrInvalidRate, err)
+				}
+			})
+		}
+	})
+
 	t.Run("Malformed XML", func(t *testing.T) {
 		xmlData := `<?xml version="1.0" encoding="UTF-8"?><MalformedXML>`
 		reader := strings.NewReader(xmlData)
@@ -122,3 +190,166 @@ func TestReadRateFromResponse(t *testing.T) {
 		}
 	})
 }
+
+// TestReadRateFromResponse_ParseError tests that decoding errors are exposed along with the offending XML.
+func TestReadRateFromResponse_ParseError(t *testing.T) {
+	xmlData := `<?xml version="1.0" encoding="UTF-8"?><gesmes:Envelope><Cube><Cube>
+			<Cube currency='USD' rate='1.25'/>
+		</Cube></Oops></gesmes:Envelope>`
+
+	_, err := readRateFromResponse("USD", "EUR", strings.NewReader(xmlData))
+	if !errors.Is(err, ErrUnexpectedFormat) {
+		t.Fatalf("expected error %v, got %v", ErrUnexpectedFormat, err)
+	}
+
+	var syntaxErr *xml.SyntaxError
+	if !errors.As(errors.Unwrap(err), &syntaxErr) {
+		t.Errorf("expected the unwrapped error to be an *xml.SyntaxError, got %v", errors.Unwrap(err))
+	}
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected a *ParseError, got %T", err)
+	}
+	if !strings.Contains(parseErr.Snippet, "</Oops>") {
+		t.Errorf("expected the snippet to contain the offending tag, got %q", parseErr.Snippet)
+	}
+	if len(parseErr.Snippet) > 2*snippetRadius {
+		t.Errorf("expected the snippet to be truncated to %d bytes, got %d", 2*snippetRadius, len(parseErr.Snippet))
+	}
+}
+
+// TestReadRateFromResponse_ReadError tests that failing to read the response is reported as a network failure, not a bad payload.
+func TestReadRateFromResponse_ReadError(t *testing.T) {
+	body := io.MultiReader(strings.NewReader(`<?xml version="1.0"?><gesmes:Envelope>`), iotest.ErrReader(errors.New("connection reset by peer")))
+
+	_, err := readRateFromResponse("USD", "EUR", body)
+	if !errors.Is(err, ErrCallingServer) {
+		t.Errorf("expected error %v, got %v", ErrCallingServer, err)
+	}
+	if errors.Is(err, ErrUnexpectedFormat) {
+		t.Errorf("expected a read error not to be %v, got %v", ErrUnexpectedFormat, err)
+	}
+}
+
+// TestReadRateFromResponse_Truncated tests that a response cut short is told apart from other format errors.
+func TestReadRateFromResponse_Truncated(t *testing.T) {
+	xmlData := `<?xml version="1.0" encoding="UTF-8"?><gesmes:Envelope><Cube><Cube>
+			<Cube currency='USD' rate='1.25'/>
+			<Cube currency='JPY' ra`
+
+	tt := map[string]struct {
+		body      io.Reader
+		truncated bool
+	}{
+		"cut in the middle of the XML": {body: strings.NewReader(xmlData), truncated: true},
+		"connection dropped":           {body: io.MultiReader(strings.NewReader(xmlData[:60]), iotest.ErrReader(io.ErrUnexpectedEOF)), truncated: true},
+		"malformed XML":                {body: strings.NewReader(`<gesmes:Envelope><Cube></Oops></gesmes:Envelope>`), truncated: false},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			_, err := readRateFromResponse("USD", "EUR", tc.body)
+			if !errors.Is(err, ErrUnexpectedFormat) {
+				t.Errorf("expected error %v, got %v", ErrUnexpectedFormat, err)
+			}
+			if got := errors.Is(err, ErrTruncatedResponse); got != tc.truncated {
+				t.Errorf("expected errors.Is(err, ErrTruncatedResponse) to be %t, got %t for %v", tc.truncated, got, err)
+			}
+		})
+	}
+}
+
+// TestReadRateOnFromResponse tests reading the rate of a given day from a multi-day XML response.
+func TestReadRateOnFromResponse(t *testing.T) {
+	// 2023-10-28 and 2023-10-29 are a weekend: the ECB doesn't publish rates on those days.
+	xmlData := `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<Cube>
+		<Cube time='2023-10-30'>
+			<Cube currency='USD' rate='1.5'/>
+		</Cube>
+		<Cube time='2023-10-27'>
+			<Cube currency='USD' rate='1.25'/>
+		</Cube>
+		<Cube time='2023-10-26'>
+			<Cube currency='USD' rate='2'/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+	tt := map[string]struct {
+		day          time.Time
+		fallbackDays int
+		expectedRate money.ExchangeRate
+		expectedDay  time.Time
+		err          error
+	}{
+		"exact day": {
+			day:          mustParseDay(t, "2023-10-26"),
+			fallbackDays: 0,
+			expectedRate: money.ExchangeRate(mustParseDecimal(t, "2")),
+			expectedDay:  mustParseDay(t, "2023-10-26"),
+		},
+		"exact day with fallback enabled": {
+			day:          mustParseDay(t, "2023-10-30"),
+			fallbackDays: 3,
+			expectedRate: money.ExchangeRate(mustParseDecimal(t, "1.5")),
+			expectedDay:  mustParseDay(t, "2023-10-30"),
+		},
+		"gap without fallback": {
+			day:          mustParseDay(t, "2023-10-29"),
+			fallbackDays: 0,
+			err:          ErrExchangeRateNotFound,
+		},
+		"gap with enough fallback": {
+			day:          mustParseDay(t, "2023-10-29"),
+			fallbackDays: 2,
+			expectedRate: money.ExchangeRate(mustParseDecimal(t, "1.25")),
+			expectedDay:  mustParseDay(t, "2023-10-27"),
+		},
+		"gap with too little fallback": {
+			day:          mustParseDay(t, "2023-10-29"),
+			fallbackDays: 1,
+			err:          ErrExchangeRateNotFound,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			rate, day, err := readRateOnFromResponse("EUR", "USD", tc.day, tc.fallbackDays, strings.NewReader(xmlData))
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+			if rate != tc.expectedRate {
+				t.Errorf("expected rate %v, got %v", tc.expectedRate, rate)
+			}
+			if !day.Equal(tc.expectedDay) {
+				t.Errorf("expected day %v, got %v", tc.expectedDay, day)
+			}
+		})
+	}
+}
+
+func mustParseDay(t *testing.T, day string) time.Time {
+	t.Helper()
+
+	d, err := time.Parse(dayLayout, day)
+	if err != nil {
+		t.Fatalf("cannot parse day %s", day)
+	}
+
+	return d
+}
+
+func TestParseError_Error(t *testing.T) {
+	err := &ParseError{Err: errors.New("boom")}
+	if got, want := err.Error(), ErrUnexpectedFormat.Error()+": boom"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	err.Snippet = "<Oops>"
+	if got, want := err.Error(), ErrUnexpectedFormat.Error()+`: boom near "<Oops>"`; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,98 @@
+package ecbank
+
+import (
+	"maps"
+	"net/http"
+	"time"
+)
+
+// Option defines a configuration function, an optional parameter to NewClient that changes the behaviour of the Client.
+type Option func(*Client)
+
+// WithFallbackToPreviousDay allows FetchExchangeRateOn to walk back, one day at a time and up to maxDays days,
+// when the ECB hasn't published rates for the requested day (weekends, holidays, or a feed that isn't out yet).
+// Use 0 to only accept the exact requested day. A negative maxDays is treated as 0: there's no day to walk back to.
+func WithFallbackToPreviousDay(maxDays int) Option {
+	return func(c *Client) {
+		c.fallbackDays = max(maxDays, 0)
+	}
+}
+
+// WithClock replaces the function the client uses to get the current time, time.Now by default.
+// It drives the cache expiry and the selection of "today". It's mostly useful in tests.
+func WithClock(now func() time.Time) Option {
+	return func(c *Client) {
+		c.now = now
+	}
+}
+
+// WithCache keeps the contents of the ECB feeds for the given duration,
+// so that successive calls don't all hit the network. The ECB updates its rates once a day.
+func WithCache(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.cache = newCache(ttl)
+	}
+}
+
+// WithFullHistory makes FetchExchangeRateOn read the ECB's full historical feed, which goes back to 1999,
+// instead of the feed of the last 90 days. The full feed weighs several megabytes:
+// it's streamed rather than loaded in memory, and it isn't cached.
+func WithFullHistory() Option {
+	return func(c *Client) {
+		c.fullHistory = true
+	}
+}
+
+// WithStaleWarning calls onStale when the latest rates are older than maxAge, e.g. to log a warning.
+// It's checked by every method that uses the latest rates: FetchExchangeRate, FetchRate, FetchEnvelope,
+// FetchExchangeRatePair and ConvertToMany. It's never called for rates of a chosen day:
+// those of FetchExchangeRateOn, or of any method of a client created with WithPinnedDate.
+// The ECB publishes new rates once per working day, so rates are at least a few hours old,
+// and a few days old after weekends and holidays. The age is counted from midnight UTC of the publication day,
+// according to the client's clock (see WithClock).
+func WithStaleWarning(maxAge time.Duration, onStale func(age time.Duration)) Option {
+	return func(c *Client) {
+		c.maxAge = maxAge
+		c.onStale = onStale
+	}
+}
+
+// WithPinnedDate makes the client always use the rates published on the given day, whatever the current date,
+// e.g. for reproducible demos and golden tests against the real feed. It applies to every method that would
+// otherwise use the latest rates: FetchExchangeRate, FetchRate, FetchEnvelope, FetchExchangeRatePair and ConvertToMany.
+// The day is looked up like with FetchExchangeRateOn, so the other options (fallback, full history) apply.
+// A zero day doesn't pin anything: the latest rates are used.
+func WithPinnedDate(day time.Time) Option {
+	return func(c *Client) {
+		c.pinnedDate = day
+	}
+}
+
+// WithCurrencyAliases makes the client accept alternative currency codes, e.g. legacy or ambiguous ones,
+// by mapping each of them to the code the ECB feed uses: {"RUR": "RUB"} makes RUR rates read RUB's.
+// Aliases are resolved once, they aren't chained. The map is copied: changing it afterwards doesn't affect the client.
+func WithCurrencyAliases(aliases map[string]string) Option {
+	return func(c *Client) {
+		c.aliases = maps.Clone(aliases)
+	}
+}
+
+// WithHeaders sets custom headers on every request the client makes, e.g. an API key for a proxy,
+// or a User-Agent identifying your application: some servers reject requests without one.
+// A header given here replaces the default value of the same header, so the default User-Agent,
+// which names this library, is only replaced if headers has one. The headers are copied:
+// changing them afterwards doesn't affect the client.
+func WithHeaders(headers http.Header) Option {
+	return func(c *Client) {
+		merged := c.headers.Clone()
+		for key, values := range headers {
+			// Set and Add canonicalize the key, e.g. "user-agent" becomes "User-Agent",
+			// so that it replaces the default header instead of being sent next to it.
+			merged.Del(key)
+			for _, value := range values {
+				merged.Add(key, value)
+			}
+		}
+		c.headers = merged
+	}
+}
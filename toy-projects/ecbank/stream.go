@@ -0,0 +1,76 @@
+package ecbank
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+)
+
+// streamDays decodes a multi-day feed token by token, and only keeps the days between from and to, included,
+// both written with dayLayout. The other days are skipped without being decoded.
+// The feed lists the most recent day first: decoding stops at the first day before from,
+// so the rest of the feed isn't even read. This keeps the memory use bounded by the number of kept days,
+// which matters for the full historical feed, that holds every day since 1999.
+func streamDays(respBody io.Reader, from, to string) (envelope, error) {
+	decoder := xml.NewDecoder(respBody)
+
+	var kept envelope
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return kept, nil
+		}
+		if err != nil {
+			return envelope{}, streamError(err)
+		}
+
+		// Daily cubes are the only cubes with a time attribute. The outer cube is walked into,
+		// and the currency cubes are inside daily cubes, which are either decoded or skipped as a whole.
+		start, isStart := token.(xml.StartElement)
+		if !isStart || start.Name.Local != "Cube" {
+			continue
+		}
+		day, found := timeAttr(start)
+		if !found {
+			continue
+		}
+
+		// Days written with dayLayout can be compared as strings.
+		switch {
+		case day < from:
+			return kept, nil
+		case day > to:
+			if err = decoder.Skip(); err != nil {
+				return envelope{}, streamError(err)
+			}
+		default:
+			var rates dailyRates
+			if err = decoder.DecodeElement(&rates, &start); err != nil {
+				return envelope{}, streamError(err)
+			}
+			kept.Days = append(kept.Days, rates)
+		}
+	}
+}
+
+// streamError reports an error met while streaming a feed. The XML decoder returns an *xml.SyntaxError
+// when the feed is malformed, and passes the errors of the reader through, e.g. when the connection drops:
+// only the former are the payload's fault, see readError.
+func streamError(err error) error {
+	var syntaxErr *xml.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		// The feed isn't kept in memory, so there's no snippet to show.
+		return &ParseError{Err: err}
+	}
+	return readError(err)
+}
+
+// timeAttr returns the value of the time attribute of an element, and whether it has one.
+func timeAttr(start xml.StartElement) (string, bool) {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "time" {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
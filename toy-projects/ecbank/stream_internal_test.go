@@ -0,0 +1,101 @@
+package ecbank
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+// historicalFeed generates a feed with a cube for every day from `to` back to `from`, most recent day first.
+// The USD rate of each day encodes its date, e.g. 2021.0315 for 2021-03-15, so that tests can tell days apart.
+func historicalFeed(from, to time.Time) string {
+	var feed strings.Builder
+	feed.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` +
+		`<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref"><Cube>`)
+	for day := to; !day.Before(from); day = day.AddDate(0, 0, -1) {
+		fmt.Fprintf(&feed, "<Cube time='%s'><Cube currency='USD' rate='%s'/><Cube currency='RON' rate='5'/></Cube>\n",
+			day.Format(dayLayout), day.Format("2006.0102"))
+	}
+	feed.WriteString(`</Cube></gesmes:Envelope>`)
+	return feed.String()
+}
+
+func TestStreamDays(t *testing.T) {
+	// Three years of daily rates.
+	feed := historicalFeed(mustParseDay(t, "2020-01-01"), mustParseDay(t, "2022-12-31"))
+
+	got, err := streamDays(strings.NewReader(feed), "2021-03-14", "2021-03-15")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Only the requested days are decoded.
+	if len(got.Days) != 2 || got.Days[0].Time != "2021-03-15" || got.Days[1].Time != "2021-03-14" {
+		t.Fatalf("expected the days 2021-03-15 and 2021-03-14, got %+v", got.Days)
+	}
+	if rates := got.Days[0].Rates; len(rates) != 2 || rates[0] != (currencyRate{Currency: "USD", Rate: "2021.0315"}) {
+		t.Errorf("unexpected rates for 2021-03-15: %+v", rates)
+	}
+}
+
+func TestStreamDays_StopsAfterTheRequestedDays(t *testing.T) {
+	feed := historicalFeed(mustParseDay(t, "2021-01-01"), mustParseDay(t, "2022-12-31"))
+
+	// Whatever follows the requested days must not even be read: make it fail if it is.
+	// The decoder reads ahead a few kilobytes, so the failure is set a few months after the requested day.
+	body := io.MultiReader(strings.NewReader(feed[:strings.Index(feed, "<Cube time='2021-04-01'>")]), errReader{})
+
+	got, err := streamDays(body, "2021-07-01", "2021-07-01")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Days) != 1 || got.Days[0].Time != "2021-07-01" {
+		t.Errorf("expected the day 2021-07-01, got %+v", got.Days)
+	}
+}
+
+func TestStreamDays_MalformedFeed(t *testing.T) {
+	_, err := streamDays(strings.NewReader(`<gesmes:Envelope><Cube><Cube time='2021-07-01'><Cube`), "2021-07-01", "2021-07-01")
+	if !errors.Is(err, ErrUnexpectedFormat) {
+		t.Errorf("expected error %v, got %v", ErrUnexpectedFormat, err)
+	}
+}
+
+func TestStreamDays_ReadError(t *testing.T) {
+	feed := `<gesmes:Envelope><Cube><Cube time='2021-07-01'>`
+
+	tt := map[string]struct {
+		readErr error
+		want    error
+	}{
+		"connection reset":   {readErr: errors.New("connection reset by peer"), want: ErrCallingServer},
+		"connection dropped": {readErr: io.ErrUnexpectedEOF, want: ErrTruncatedResponse},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			body := io.MultiReader(strings.NewReader(feed), iotest.ErrReader(tc.readErr))
+
+			_, err := streamDays(body, "2021-07-01", "2021-07-01")
+			if !errors.Is(err, tc.want) {
+				t.Errorf("expected error %v, got %v", tc.want, err)
+			}
+			var parseErr *ParseError
+			if errors.As(err, &parseErr) {
+				t.Errorf("expected a read error not to be a ParseError, got %v", err)
+			}
+		})
+	}
+}
+
+// errReader is an io.Reader that always fails.
+type errReader struct{}
+
+// Read implements the io.Reader interface.
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("this part of the feed should not be read")
+}
@@ -2,6 +2,8 @@
 // including currencies, decimal amounts, and currency conversion.
 package money
 
+import "strings"
+
 // Amount defines a decimal of money in a given currency.
 // It combines a Decimal value with a Currency type.
 type Amount struct {
@@ -11,11 +13,45 @@ type Amount struct {
 	currency Currency
 }
 
-// Predefined error for amounts that are too precise for their currency.
+// Predefined errors for invalid amounts and operations on amounts.
 const (
 	// ErrTooPrecise is returned if the number is too precise for the currency.
 	// For example, trying to represent 1.234 EUR when EUR only supports 2 decimal places.
 	ErrTooPrecise = MoneyError("amount quantity is too precise for its currency")
+
+	// ErrCurrencyMismatch is returned when an operation requires amounts of the same currency.
+	// For example, comparing 1.00 EUR with 1.00 USD.
+	ErrCurrencyMismatch = MoneyError("amounts have different currencies")
+
+	// ErrNoAmounts is returned when an operation requires at least one amount.
+	ErrNoAmounts = MoneyError("no amounts provided")
+
+	// ErrDivisionByZero is returned when an amount is divided by a zero amount.
+	ErrDivisionByZero = MoneyError("division by a zero amount")
+
+	// ErrNoShares is returned when an amount is split into less than one share.
+	ErrNoShares = MoneyError("amount must be split into at least one share")
+
+	// ErrPrecisionLoss is returned when lowering an amount's precision would drop nonzero digits.
+	// For example, 1.55 EUR can't be expressed with a single decimal place.
+	ErrPrecisionLoss = MoneyError("amount can't be expressed at a lower precision without losing digits")
+
+	// ErrNonPositiveStep is returned when an amount is rounded to a step that is zero or negative.
+	ErrNonPositiveStep = MoneyError("rounding step must be positive")
+
+	// ErrBelowMinimum is returned when an amount is too small to give every share a minimum.
+	// For example, 10.00 EUR can't be split into 3 shares of at least 4.00 EUR.
+	ErrBelowMinimum = MoneyError("amount is too small to give every share the minimum")
+
+	// ErrNegativePercentage is returned when a percentage of an amount is negative.
+	ErrNegativePercentage = MoneyError("percentage must not be negative")
+
+	// ErrNegativeResult is returned when an operation that forbids negative amounts would produce one.
+	// For example, 10.00 EUR minus 12.50 EUR with SubtractNonNegative.
+	ErrNegativeResult = MoneyError("result would be negative")
+
+	// ErrInvalidAmount is returned when a string doesn't represent an amount, like "19.99 USD".
+	ErrInvalidAmount = MoneyError("invalid amount: must be a decimal and a currency code separated by a space")
 )
 
 // NewAmount returns an Amount of money.
@@ -42,6 +78,29 @@ func NewAmount(quantity Decimal, currency Currency) (Amount, error) {
 	return Amount{quantity: quantity, currency: currency}, nil
 }
 
+// ParseAmount converts a string such as "19.99 USD" into an Amount. It's the inverse of Amount.String.
+// The decimal and the currency code can be separated by any whitespace.
+// It returns ErrInvalidAmount if there aren't exactly two parts,
+// and the errors of ParseDecimal, ParseCurrency and NewAmount if a part is invalid.
+func ParseAmount(s string) (Amount, error) {
+	parts := strings.Fields(s)
+	if len(parts) != 2 {
+		return Amount{}, ErrInvalidAmount
+	}
+
+	quantity, err := ParseDecimal(parts[0])
+	if err != nil {
+		return Amount{}, err
+	}
+
+	currency, err := ParseCurrency(parts[1])
+	if err != nil {
+		return Amount{}, err
+	}
+
+	return NewAmount(quantity, currency)
+}
+
 // validate checks if an Amount is internally consistent and within supported limits.
 // It's typically used after calculations to ensure the result is valid.
 func (a Amount) validate() error {
@@ -58,6 +117,340 @@ func (a Amount) validate() error {
 	return nil
 }
 
+// WithPrecision returns the same amount, expressed with p decimal places.
+// Raising the precision adds trailing zeros (1.5 becomes 1.50), lowering it removes them (1.50 becomes 1.5).
+// It's handy to align amounts before comparing or adding their subunits.
+// It returns ErrPrecisionLoss if lowering the precision would drop nonzero digits,
+// ErrTooPrecise if p is more than the currency's precision, and ErrTooLarge if the result overflows.
+func (a Amount) WithPrecision(p byte) (Amount, error) {
+	switch {
+	case p > a.currency.precision:
+		return Amount{}, ErrTooPrecise
+	case p > a.quantity.precision:
+		a.quantity.subunits *= pow10(p - a.quantity.precision)
+	case p < a.quantity.precision:
+		factor := pow10(a.quantity.precision - p)
+		if a.quantity.subunits%factor != 0 {
+			return Amount{}, ErrPrecisionLoss
+		}
+		a.quantity.subunits /= factor
+	}
+	a.quantity.precision = p
+
+	if err := a.validate(); err != nil {
+		return Amount{}, err
+	}
+	return a, nil
+}
+
+// Neg returns the amount with its sign flipped, in the same currency and with the same precision.
+// For example, 12.50 EUR becomes -12.50 EUR, which is handy to represent a refund.
+func (a Amount) Neg() Amount {
+	a.quantity.subunits = -a.quantity.subunits
+	return a
+}
+
+// Abs returns the absolute value of the amount, in the same currency and with the same precision.
+func (a Amount) Abs() Amount {
+	if a.quantity.subunits < 0 {
+		return a.Neg()
+	}
+	return a
+}
+
+// IsNegative tells whether the amount is below zero, e.g. an overdrawn balance. Zero isn't negative.
+func (a Amount) IsNegative() bool {
+	return a.quantity.subunits < 0
+}
+
+// IsPositive tells whether the amount is above zero. Zero isn't positive.
+// The precision doesn't matter: 0.01 EUR is positive, and 0.00 EUR is as zero as 0 EUR.
+func (a Amount) IsPositive() bool {
+	return a.quantity.subunits > 0
+}
+
+// Compare compares two amounts of the same currency.
+// It returns -1 if a is less than b, 0 if they are equal, and +1 if a is greater than b.
+// It returns ErrCurrencyMismatch if the currencies differ.
+func (a Amount) Compare(b Amount) (int, error) {
+	if a.currency != b.currency {
+		return 0, ErrCurrencyMismatch
+	}
+
+	// Both quantities are brought to the same precision, so that their subunits can be compared.
+	// For example, 1.5 {15, 1} and 1.50 {150, 2} are equal.
+	x, y := a.quantity.subunits, b.quantity.subunits
+	switch {
+	case a.quantity.precision < b.quantity.precision:
+		x *= pow10(b.quantity.precision - a.quantity.precision)
+	case a.quantity.precision > b.quantity.precision:
+		y *= pow10(a.quantity.precision - b.quantity.precision)
+	}
+
+	switch {
+	case x < y:
+		return -1, nil
+	case x > y:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// Ratio returns a divided by b, e.g. to compute the weight of an amount in a total.
+// Both amounts must be of the same currency, and b must not be zero.
+// The result is a float64: unlike amounts, it can't represent every decimal exactly,
+// so it's meant for analytics, not for further money computations.
+func (a Amount) Ratio(b Amount) (float64, error) {
+	if a.currency != b.currency {
+		return 0, ErrCurrencyMismatch
+	}
+	if b.quantity.subunits == 0 {
+		return 0, ErrDivisionByZero
+	}
+
+	// Dividing the subunits only works if both quantities have the same precision.
+	x, y := float64(a.quantity.subunits), float64(b.quantity.subunits)
+	if a.quantity.precision < b.quantity.precision {
+		x *= float64(pow10(b.quantity.precision - a.quantity.precision))
+	} else {
+		y *= float64(pow10(a.quantity.precision - b.quantity.precision))
+	}
+
+	return x / y, nil
+}
+
+// Subtract returns a - b, e.g. a balance after a payment. The result may be negative:
+// 10.00 EUR - 12.50 EUR is -2.50 EUR. Use SubtractNonNegative where negative results are forbidden.
+// It returns ErrCurrencyMismatch if the currencies differ, and ErrTooLarge if the result overflows.
+func (a Amount) Subtract(b Amount) (Amount, error) {
+	if a.currency != b.currency {
+		return Amount{}, ErrCurrencyMismatch
+	}
+
+	// Both quantities are brought to the same precision, so that their subunits can be subtracted.
+	precision := max(a.quantity.precision, b.quantity.precision)
+	x := a.quantity.subunits * pow10(precision-a.quantity.precision)
+	y := b.quantity.subunits * pow10(precision-b.quantity.precision)
+
+	a.quantity = Decimal{subunits: x - y, precision: precision}
+	// validate only checks the upper bound: a large negative result is checked through its absolute value.
+	if err := a.Abs().validate(); err != nil {
+		return Amount{}, err
+	}
+	return a, nil
+}
+
+// SubtractNonNegative is like Subtract, for domains where balances can't go below zero, such as a prepaid account.
+// It returns ErrNegativeResult, instead of a negative amount, if b is larger than a. A zero result is fine.
+func (a Amount) SubtractNonNegative(b Amount) (Amount, error) {
+	diff, err := a.Subtract(b)
+	if err != nil {
+		return Amount{}, err
+	}
+	if diff.IsNegative() {
+		return Amount{}, ErrNegativeResult
+	}
+	return diff, nil
+}
+
+// Diff returns the distance between a and b, |a - b|, and whether a is greater than or equal to b,
+// e.g. to show a budget delta as "12.50 EUR over" or "12.50 EUR under" without juggling signs.
+// For example, 10.00 EUR and 12.50 EUR are 2.50 EUR apart, and the bool is false.
+// It returns ErrCurrencyMismatch if the currencies differ, and ErrTooLarge if the distance overflows.
+func (a Amount) Diff(b Amount) (Amount, bool, error) {
+	diff, err := a.Subtract(b)
+	if err != nil {
+		return Amount{}, false, err
+	}
+	return diff.Abs(), !diff.IsNegative(), nil
+}
+
+// Distribute splits the amount into n shares that are as equal as possible.
+// The shares always add up exactly to the original amount: the subunits that can't be split evenly
+// are spread one by one over the first shares. For example, 1.00 USD split in 3 gives 0.34, 0.33 and 0.33 USD.
+// It returns ErrNoShares if n isn't positive.
+func (a Amount) Distribute(n int) ([]Amount, error) {
+	if n <= 0 {
+		return nil, ErrNoShares
+	}
+
+	// With a negative amount, the remainder is negative too, and the first shares get one subunit less.
+	base := a.quantity.subunits / int64(n)
+	remainder := a.quantity.subunits % int64(n)
+
+	step := int64(1)
+	if remainder < 0 {
+		step, remainder = -1, -remainder
+	}
+
+	shares := make([]Amount, n)
+	for i := range shares {
+		share := a
+		share.quantity.subunits = base
+		if int64(i) < remainder {
+			share.quantity.subunits += step
+		}
+		shares[i] = share
+	}
+	return shares, nil
+}
+
+// SplitWithMinimum splits the amount into n shares that are each at least minimum, e.g. for payouts with a floor.
+// Every share first receives minimum, then what is left over is distributed as evenly as possible, as in Distribute.
+// For example, 10.00 EUR split in 3 with a minimum of 3.00 EUR gives 3.34, 3.33 and 3.33 EUR.
+// It returns ErrNoShares if n isn't positive, ErrCurrencyMismatch if minimum isn't in the amount's currency,
+// ErrBelowMinimum if the amount is smaller than n times minimum, and ErrTooLarge if n times minimum overflows.
+func (a Amount) SplitWithMinimum(n int, minimum Amount) ([]Amount, error) {
+	if n <= 0 {
+		return nil, ErrNoShares
+	}
+	if a.currency != minimum.currency {
+		return nil, ErrCurrencyMismatch
+	}
+
+	// Both quantities are brought to the same precision, so that their subunits can be subtracted.
+	precision := max(a.quantity.precision, minimum.quantity.precision)
+	total, totalFits := multiplyInt64(a.quantity.subunits, pow10(precision-a.quantity.precision))
+	floor, floorFits := multiplyInt64(minimum.quantity.subunits, pow10(precision-minimum.quantity.precision))
+	owed, owedFits := multiplyInt64(int64(n), floor)
+	if !totalFits || !floorFits || !owedFits {
+		return nil, ErrTooLarge
+	}
+
+	// A negative minimum makes the subtraction an addition, which can overflow too.
+	remaining := total - owed
+	if (total^owed)&(total^remaining) < 0 {
+		return nil, ErrTooLarge
+	}
+
+	leftover := Amount{
+		quantity: Decimal{subunits: remaining, precision: precision},
+		currency: a.currency,
+	}
+	if leftover.quantity.subunits < 0 {
+		return nil, ErrBelowMinimum
+	}
+
+	shares, err := leftover.Distribute(n)
+	if err != nil {
+		return nil, err
+	}
+	for i := range shares {
+		shares[i].quantity.subunits += floor
+	}
+	return shares, nil
+}
+
+// multiplyInt64 returns x × y, and whether the product fits in an int64.
+// Go doesn't report integer overflows: the product silently wraps around, e.g. to a negative number.
+// Dividing it back by y tells whether it did.
+func multiplyInt64(x, y int64) (int64, bool) {
+	if y == 0 {
+		return 0, true
+	}
+	product := x * y
+	return product, product/y == x
+}
+
+// RoundToNearest rounds the amount to the nearest multiple of step, e.g. to the nearest 0.05 CHF for cash payments.
+// Amounts exactly halfway between two multiples are rounded away from zero: 2.50 to the nearest 1.00 is 3.00.
+// The step must be positive, and of the same currency as the amount.
+// It returns ErrCurrencyMismatch if the currencies differ, and ErrNonPositiveStep if the step isn't positive.
+func (a Amount) RoundToNearest(step Amount) (Amount, error) {
+	if a.currency != step.currency {
+		return Amount{}, ErrCurrencyMismatch
+	}
+	if step.quantity.subunits <= 0 {
+		return Amount{}, ErrNonPositiveStep
+	}
+
+	// Both quantities are brought to the same precision, so that their subunits can be divided.
+	x, y := a.quantity.subunits, step.quantity.subunits
+	precision := max(a.quantity.precision, step.quantity.precision)
+	x *= pow10(precision - a.quantity.precision)
+	y *= pow10(precision - step.quantity.precision)
+
+	a.quantity = Decimal{subunits: divideRounded(x, y) * y, precision: precision}
+	if err := a.validate(); err != nil {
+		return Amount{}, err
+	}
+	return a, nil
+}
+
+// Percentage returns pct percent of the amount, e.g. the tax or the tip of an invoice: 20% of 100.00 EUR is 20.00 EUR.
+// The result is rounded to the precision of the currency, halfway values away from zero: 15% of 0.10 EUR is 0.02 EUR.
+// It returns ErrNegativePercentage if pct is negative, and ErrTooLarge if the result overflows.
+func (a Amount) Percentage(pct int) (Amount, error) {
+	if pct < 0 {
+		return Amount{}, ErrNegativePercentage
+	}
+
+	// pct percent is the rate pct/100, which is pct subunits with a precision of 2.
+	product := multiply(a.quantity, ExchangeRate{subunits: int64(pct), precision: 2})
+	if product.precision > a.currency.precision {
+		factor := pow10(product.precision - a.currency.precision)
+		product = Decimal{subunits: divideRounded(product.subunits, factor), precision: a.currency.precision}
+	}
+	// The product is simplified: 20% of 100.00 is 20, which is written back with the currency's decimals, 20.00.
+	if product.precision < a.currency.precision {
+		product = Decimal{subunits: product.subunits * pow10(a.currency.precision-product.precision), precision: a.currency.precision}
+	}
+
+	a.quantity = product
+	if err := a.validate(); err != nil {
+		return Amount{}, err
+	}
+	return a, nil
+}
+
+// divideRounded returns x divided by the positive y, rounded to the nearest integer, halfway values away from zero.
+func divideRounded(x, y int64) int64 {
+	// Integer division truncates towards zero: move one further away from zero
+	// when the remainder is at least half of y.
+	quotient, remainder := x/y, x%y
+	switch {
+	case 2*remainder >= y:
+		quotient++
+	case 2*remainder <= -y:
+		quotient--
+	}
+	return quotient
+}
+
+// Max returns the largest of the given amounts, which must all be of the same currency.
+// When several amounts are the largest, the first one is returned.
+func Max(amounts ...Amount) (Amount, error) {
+	return pick(amounts, 1)
+}
+
+// Min returns the smallest of the given amounts, which must all be of the same currency.
+// When several amounts are the smallest, the first one is returned.
+func Min(amounts ...Amount) (Amount, error) {
+	return pick(amounts, -1)
+}
+
+// pick returns the first amount that no other amount beats in the given direction:
+// 1 picks the largest amount, -1 picks the smallest.
+func pick(amounts []Amount, direction int) (Amount, error) {
+	if len(amounts) == 0 {
+		return Amount{}, ErrNoAmounts
+	}
+
+	best := amounts[0]
+	for _, a := range amounts[1:] {
+		cmp, err := a.Compare(best)
+		if err != nil {
+			return Amount{}, err
+		}
+		// Only replace on a strict win, so that the first of equal amounts is kept.
+		if cmp == direction {
+			best = a
+		}
+	}
+	return best, nil
+}
+
 // String implements the fmt.Stringer interface for the Amount type.
 // It returns a string representation like "123.45 EUR".
 func (a Amount) String() string {
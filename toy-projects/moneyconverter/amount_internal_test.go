@@ -4,6 +4,7 @@ package money
 import (
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"testing"
 )
@@ -88,6 +89,92 @@ func TestAmount_String(t *testing.T) {
 	}
 }
 
+func TestAmount_Format(t *testing.T) {
+	amount := mustNewAmount(t, "19.99", "USD")
+
+	tt := map[string]struct {
+		format string
+		want   string
+	}{
+		"%v":                 {format: "%v", want: "19.99 USD"},
+		"%s":                 {format: "%s", want: "19.99 USD"},
+		"%q":                 {format: "%q", want: `"19.99 USD"`},
+		"%f":                 {format: "%f", want: "19.99"},
+		"quoted & truncated": {format: "%.1q", want: `"19.9 USD"`},
+		"padded":             {format: "[%12v]", want: "[   19.99 USD]"},
+		"left-aligned":       {format: "[%-12v]", want: "[19.99 USD   ]"},
+		"width too narrow":   {format: "%3v", want: "19.99 USD"},
+		"truncated":          {format: "%.0v", want: "19 USD"},
+		"truncated quantity": {format: "%.1f", want: "19.9"},
+		"extra precision":    {format: "%.4v", want: "19.99 USD"},
+		"padded & truncated": {format: "[%8.0f]", want: "[      19]"},
+		"bad verb":           {format: "%d", want: "%!d(money.Amount=19.99 USD)"},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			if got := fmt.Sprintf(tc.format, amount); got != tc.want {
+				t.Errorf("Sprintf(%q) = %q, want %q", tc.format, got, tc.want)
+			}
+		})
+	}
+
+	t.Run("negative", func(t *testing.T) {
+		if got := fmt.Sprintf("%.1v", mustNewAmount(t, "-1.99", "USD")); got != "-1.9 USD" {
+			t.Errorf("Sprintf(%%.1v) = %q, want %q", got, "-1.9 USD")
+		}
+	})
+}
+
+func TestParseAmount(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		for _, amount := range []Amount{
+			mustNewAmount(t, "19.99", "USD"),
+			mustNewAmount(t, "-3.50", "EUR"),
+			mustNewAmount(t, "1500", "IRR"),
+			mustNewAmount(t, "1.234", "BHD"),
+		} {
+			got, err := ParseAmount(amount.String())
+			if err != nil {
+				t.Fatalf("ParseAmount(%q) returned an unexpected error: %v", amount.String(), err)
+			}
+			if got != amount {
+				t.Errorf("ParseAmount(%q) = %v, want %v", amount.String(), got, amount)
+			}
+		}
+	})
+
+	t.Run("any whitespace", func(t *testing.T) {
+		got, err := ParseAmount("  19.99\tUSD ")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := mustNewAmount(t, "19.99", "USD"); got != want {
+			t.Errorf("ParseAmount() = %v, want %v", got, want)
+		}
+	})
+
+	tt := map[string]struct {
+		s   string
+		err error
+	}{
+		"empty":            {s: "", err: ErrInvalidAmount},
+		"missing currency": {s: "19.99", err: ErrInvalidAmount},
+		"extra token":      {s: "19.99 USD now", err: ErrInvalidAmount},
+		"invalid decimal":  {s: "nineteen USD", err: ErrInvalidDecimal},
+		"invalid currency": {s: "19.99 DOLLARS", err: ErrInvalidCurrencyCode},
+		"too precise":      {s: "19.999 USD", err: ErrTooPrecise},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseAmount(tc.s); !errors.Is(err, tc.err) {
+				t.Errorf("ParseAmount(%q): expected error %v, got %v", tc.s, tc.err, err)
+			}
+		})
+	}
+}
+
 func TestAmount_validate(t *testing.T) {
 	eur := Currency{code: "EUR", precision: 2}
 
@@ -131,6 +218,488 @@ func TestAmount_validate(t *testing.T) {
 	})
 }
 
+func TestAmount_WithPrecision(t *testing.T) {
+	eur := mustParseCurrency(t, "EUR")
+
+	tt := map[string]struct {
+		amount    Amount
+		precision byte
+		want      Amount
+		err       error
+	}{
+		"scale up": {
+			amount:    Amount{quantity: Decimal{subunits: 15, precision: 1}, currency: eur},
+			precision: 2,
+			want:      Amount{quantity: Decimal{subunits: 150, precision: 2}, currency: eur},
+		},
+		"exact scale down": {
+			amount:    Amount{quantity: Decimal{subunits: 150, precision: 2}, currency: eur},
+			precision: 1,
+			want:      Amount{quantity: Decimal{subunits: 15, precision: 1}, currency: eur},
+		},
+		"same precision": {
+			amount:    Amount{quantity: Decimal{subunits: 155, precision: 2}, currency: eur},
+			precision: 2,
+			want:      Amount{quantity: Decimal{subunits: 155, precision: 2}, currency: eur},
+		},
+		"negative amount": {
+			amount:    Amount{quantity: Decimal{subunits: -200, precision: 2}, currency: eur},
+			precision: 0,
+			want:      Amount{quantity: Decimal{subunits: -2, precision: 0}, currency: eur},
+		},
+		"lossy scale down": {
+			amount:    Amount{quantity: Decimal{subunits: 155, precision: 2}, currency: eur},
+			precision: 1,
+			err:       ErrPrecisionLoss,
+		},
+		"more precise than the currency": {
+			amount:    Amount{quantity: Decimal{subunits: 15, precision: 1}, currency: eur},
+			precision: 3,
+			err:       ErrTooPrecise,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.amount.WithPrecision(tc.precision)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+			if got != tc.want {
+				t.Errorf("WithPrecision() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAmount_Neg(t *testing.T) {
+	amount := mustNewAmount(t, "12.50", "EUR")
+
+	neg := amount.Neg()
+	if got, want := neg.String(), "-12.50 EUR"; got != want {
+		t.Errorf("Neg() = %q, want %q", got, want)
+	}
+
+	if got := neg.Neg(); got != amount {
+		t.Errorf("Neg(Neg(a)) = %v, want %v", got, amount)
+	}
+}
+
+func TestAmount_Abs(t *testing.T) {
+	tt := map[string]struct {
+		amount Amount
+		want   Amount
+	}{
+		"negative": {
+			amount: mustNewAmount(t, "-3.5", "USD"),
+			want:   mustNewAmount(t, "3.5", "USD"),
+		},
+		"positive": {
+			amount: mustNewAmount(t, "3.5", "USD"),
+			want:   mustNewAmount(t, "3.5", "USD"),
+		},
+		"zero": {
+			amount: mustNewAmount(t, "0", "JPY"),
+			want:   mustNewAmount(t, "0", "JPY"),
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.amount.Abs(); got != tc.want {
+				t.Errorf("Abs() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAmount_IsNegativeIsPositive(t *testing.T) {
+	tt := map[string]struct {
+		amount             Amount
+		negative, positive bool
+	}{
+		"positive":                 {amount: mustNewAmount(t, "12.50", "EUR"), positive: true},
+		"smallest positive":        {amount: mustNewAmount(t, "0.001", "KWD"), positive: true},
+		"negative":                 {amount: mustNewAmount(t, "-3.5", "USD"), negative: true},
+		"negative without decimal": {amount: mustNewAmount(t, "-7", "IRR"), negative: true},
+		"zero":                     {amount: mustNewAmount(t, "0", "EUR")},
+		"zero with decimals":       {amount: mustNewAmount(t, "0.000", "KWD")},
+		"zero at lower precision":  {amount: Amount{quantity: Decimal{subunits: 0, precision: 0}, currency: Currency{code: "EUR", precision: 2}}},
+		"negated zero":             {amount: mustNewAmount(t, "0", "EUR").Neg()},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.amount.IsNegative(); got != tc.negative {
+				t.Errorf("IsNegative() = %t, want %t", got, tc.negative)
+			}
+			if got := tc.amount.IsPositive(); got != tc.positive {
+				t.Errorf("IsPositive() = %t, want %t", got, tc.positive)
+			}
+		})
+	}
+}
+
+func TestAmount_Compare(t *testing.T) {
+	tt := map[string]struct {
+		a, b Amount
+		want int
+		err  error
+	}{
+		"less":    {a: mustNewAmount(t, "1.50", "EUR"), b: mustNewAmount(t, "2", "EUR"), want: -1},
+		"greater": {a: mustNewAmount(t, "2", "EUR"), b: mustNewAmount(t, "-3", "EUR"), want: 1},
+		"equal":   {a: mustNewAmount(t, "2", "EUR"), b: mustNewAmount(t, "2.00", "EUR"), want: 0},
+		"different precision": {
+			a:    Amount{quantity: Decimal{subunits: 15, precision: 1}, currency: Currency{code: "EUR", precision: 2}},
+			b:    mustNewAmount(t, "1.50", "EUR"),
+			want: 0,
+		},
+		"currency mismatch": {a: mustNewAmount(t, "1", "EUR"), b: mustNewAmount(t, "1", "USD"), err: ErrCurrencyMismatch},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.a.Compare(tc.b)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+			if got != tc.want {
+				t.Errorf("Compare() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAmount_Ratio(t *testing.T) {
+	tt := map[string]struct {
+		a, b Amount
+		want float64
+		err  error
+	}{
+		"2:1":                   {a: mustNewAmount(t, "10", "EUR"), b: mustNewAmount(t, "5", "EUR"), want: 2},
+		"1:4":                   {a: mustNewAmount(t, "0.25", "EUR"), b: mustNewAmount(t, "1", "EUR"), want: 0.25},
+		"negative":              {a: mustNewAmount(t, "-3", "EUR"), b: mustNewAmount(t, "1.50", "EUR"), want: -2},
+		"different precision":   {a: Amount{quantity: Decimal{subunits: 3, precision: 0}, currency: Currency{code: "EUR", precision: 2}}, b: mustNewAmount(t, "1.50", "EUR"), want: 2},
+		"mismatched currencies": {a: mustNewAmount(t, "10", "EUR"), b: mustNewAmount(t, "5", "USD"), err: ErrCurrencyMismatch},
+		"division by zero":      {a: mustNewAmount(t, "10", "EUR"), b: mustNewAmount(t, "0", "EUR"), err: ErrDivisionByZero},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.a.Ratio(tc.b)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+			if got != tc.want {
+				t.Errorf("Ratio() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAmount_Subtract(t *testing.T) {
+	tt := map[string]struct {
+		a, b        Amount
+		want        Amount
+		err         error
+		nonNegative Amount
+		nonNegErr   error
+	}{
+		"positive result": {
+			a: mustNewAmount(t, "12.50", "EUR"), b: mustNewAmount(t, "10", "EUR"),
+			want: mustNewAmount(t, "2.50", "EUR"), nonNegative: mustNewAmount(t, "2.50", "EUR"),
+		},
+		"zero result": {
+			a: mustNewAmount(t, "10", "EUR"), b: mustNewAmount(t, "10.00", "EUR"),
+			want: mustNewAmount(t, "0", "EUR"), nonNegative: mustNewAmount(t, "0", "EUR"),
+		},
+		"negative result": {
+			a: mustNewAmount(t, "10", "EUR"), b: mustNewAmount(t, "12.50", "EUR"),
+			want: mustNewAmount(t, "-2.50", "EUR"), nonNegErr: ErrNegativeResult,
+		},
+		"different precision": {
+			a: Amount{quantity: Decimal{subunits: 3, precision: 0}, currency: Currency{code: "EUR", precision: 2}}, b: mustNewAmount(t, "4.25", "EUR"),
+			want: mustNewAmount(t, "-1.25", "EUR"), nonNegErr: ErrNegativeResult,
+		},
+		"mismatched currencies": {
+			a: mustNewAmount(t, "10", "EUR"), b: mustNewAmount(t, "5", "USD"),
+			err: ErrCurrencyMismatch, nonNegErr: ErrCurrencyMismatch,
+		},
+		"overflow": {
+			a: Amount{quantity: Decimal{subunits: -maxDecimal, precision: 0}, currency: Currency{code: "EUR", precision: 2}}, b: mustNewAmount(t, "1", "EUR"),
+			err: ErrTooLarge, nonNegErr: ErrTooLarge,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.a.Subtract(tc.b)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Subtract() expected error %v, got %v", tc.err, err)
+			}
+			if got != tc.want {
+				t.Errorf("Subtract() = %v, want %v", got, tc.want)
+			}
+
+			got, err = tc.a.SubtractNonNegative(tc.b)
+			if !errors.Is(err, tc.nonNegErr) {
+				t.Fatalf("SubtractNonNegative() expected error %v, got %v", tc.nonNegErr, err)
+			}
+			if got != tc.nonNegative {
+				t.Errorf("SubtractNonNegative() = %v, want %v", got, tc.nonNegative)
+			}
+		})
+	}
+}
+
+func TestAmount_Diff(t *testing.T) {
+	tt := map[string]struct {
+		a, b    Amount
+		want    Amount
+		greater bool
+		err     error
+	}{
+		"a greater":             {a: mustNewAmount(t, "12.50", "EUR"), b: mustNewAmount(t, "10", "EUR"), want: mustNewAmount(t, "2.50", "EUR"), greater: true},
+		"a smaller":             {a: mustNewAmount(t, "10", "EUR"), b: mustNewAmount(t, "12.50", "EUR"), want: mustNewAmount(t, "2.50", "EUR"), greater: false},
+		"equal":                 {a: mustNewAmount(t, "7.25", "EUR"), b: mustNewAmount(t, "7.25", "EUR"), want: mustNewAmount(t, "0", "EUR"), greater: true},
+		"negative amounts":      {a: mustNewAmount(t, "-3", "EUR"), b: mustNewAmount(t, "1.50", "EUR"), want: mustNewAmount(t, "4.50", "EUR"), greater: false},
+		"different precision":   {a: Amount{quantity: Decimal{subunits: 3, precision: 0}, currency: Currency{code: "EUR", precision: 2}}, b: mustNewAmount(t, "1.25", "EUR"), want: mustNewAmount(t, "1.75", "EUR"), greater: true},
+		"mismatched currencies": {a: mustNewAmount(t, "10", "EUR"), b: mustNewAmount(t, "5", "USD"), err: ErrCurrencyMismatch},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			got, greater, err := tc.a.Diff(tc.b)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+			if got != tc.want || greater != tc.greater {
+				t.Errorf("Diff() = %v, %t, want %v, %t", got, greater, tc.want, tc.greater)
+			}
+		})
+	}
+}
+
+func TestAmount_Distribute(t *testing.T) {
+	tt := map[string]struct {
+		amount Amount
+		n      int
+		want   []Amount
+		err    error
+	}{
+		"3 shares": {
+			amount: mustNewAmount(t, "1.00", "USD"),
+			n:      3,
+			want:   []Amount{mustNewAmount(t, "0.34", "USD"), mustNewAmount(t, "0.33", "USD"), mustNewAmount(t, "0.33", "USD")},
+		},
+		"1 share": {
+			amount: mustNewAmount(t, "1.00", "USD"),
+			n:      1,
+			want:   []Amount{mustNewAmount(t, "1.00", "USD")},
+		},
+		"negative amount": {
+			amount: mustNewAmount(t, "-1.00", "USD"),
+			n:      3,
+			want:   []Amount{mustNewAmount(t, "-0.34", "USD"), mustNewAmount(t, "-0.33", "USD"), mustNewAmount(t, "-0.33", "USD")},
+		},
+		"no shares":       {amount: mustNewAmount(t, "1.00", "USD"), n: 0, err: ErrNoShares},
+		"negative shares": {amount: mustNewAmount(t, "1.00", "USD"), n: -2, err: ErrNoShares},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.amount.Distribute(tc.n)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Distribute() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAmount_SplitWithMinimum(t *testing.T) {
+	tt := map[string]struct {
+		amount Amount
+		n      int
+		min    Amount
+		want   []Amount
+		err    error
+	}{
+		"leftover above the minimum": {
+			amount: mustNewAmount(t, "10.00", "EUR"),
+			n:      3,
+			min:    mustNewAmount(t, "3.00", "EUR"),
+			want:   []Amount{mustNewAmount(t, "3.34", "EUR"), mustNewAmount(t, "3.33", "EUR"), mustNewAmount(t, "3.33", "EUR")},
+		},
+		"exactly the minimum": {
+			amount: mustNewAmount(t, "9.00", "EUR"),
+			n:      3,
+			min:    mustNewAmount(t, "3.00", "EUR"),
+			want:   []Amount{mustNewAmount(t, "3.00", "EUR"), mustNewAmount(t, "3.00", "EUR"), mustNewAmount(t, "3.00", "EUR")},
+		},
+		"zero minimum": {
+			amount: mustNewAmount(t, "1.00", "USD"),
+			n:      3,
+			min:    mustNewAmount(t, "0", "USD"),
+			want:   []Amount{mustNewAmount(t, "0.34", "USD"), mustNewAmount(t, "0.33", "USD"), mustNewAmount(t, "0.33", "USD")},
+		},
+		"below the minimum":     {amount: mustNewAmount(t, "8.99", "EUR"), n: 3, min: mustNewAmount(t, "3.00", "EUR"), err: ErrBelowMinimum},
+		"mismatched currencies": {amount: mustNewAmount(t, "10.00", "EUR"), n: 3, min: mustNewAmount(t, "3.00", "USD"), err: ErrCurrencyMismatch},
+		"no shares":             {amount: mustNewAmount(t, "10.00", "EUR"), n: 0, min: mustNewAmount(t, "3.00", "EUR"), err: ErrNoShares},
+		"minimums overflow":     {amount: mustNewAmount(t, "10.00", "EUR"), n: math.MaxInt64 / 100, min: mustNewAmount(t, "3.00", "EUR"), err: ErrTooLarge},
+		"leftover overflows":    {amount: mustNewAmount(t, "10.00", "EUR"), n: math.MaxInt64 / 100, min: mustNewAmount(t, "-1.00", "EUR"), err: ErrTooLarge},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.amount.SplitWithMinimum(tc.n, tc.min)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("SplitWithMinimum() = %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if cmp, _ := got[i].Compare(tc.want[i]); cmp != 0 {
+					t.Errorf("SplitWithMinimum() = %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestAmount_RoundToNearest(t *testing.T) {
+	fiveCents := mustNewAmount(t, "0.05", "CHF")
+
+	tt := map[string]struct {
+		amount Amount
+		step   Amount
+		want   Amount
+		err    error
+	}{
+		"round down":            {amount: mustNewAmount(t, "1.22", "CHF"), step: fiveCents, want: mustNewAmount(t, "1.20", "CHF")},
+		"round up":              {amount: mustNewAmount(t, "1.23", "CHF"), step: fiveCents, want: mustNewAmount(t, "1.25", "CHF")},
+		"already a multiple":    {amount: mustNewAmount(t, "1.25", "CHF"), step: fiveCents, want: mustNewAmount(t, "1.25", "CHF")},
+		"halfway":               {amount: mustNewAmount(t, "1.50", "CHF"), step: mustNewAmount(t, "1", "CHF"), want: mustNewAmount(t, "2", "CHF")},
+		"negative halfway":      {amount: mustNewAmount(t, "-1.50", "CHF"), step: mustNewAmount(t, "1", "CHF"), want: mustNewAmount(t, "-2", "CHF")},
+		"negative":              {amount: mustNewAmount(t, "-1.23", "CHF"), step: fiveCents, want: mustNewAmount(t, "-1.25", "CHF")},
+		"step above one":        {amount: mustNewAmount(t, "1234", "CHF"), step: mustNewAmount(t, "100", "CHF"), want: mustNewAmount(t, "1200", "CHF")},
+		"mismatched currencies": {amount: mustNewAmount(t, "1.23", "EUR"), step: fiveCents, err: ErrCurrencyMismatch},
+		"zero step":             {amount: mustNewAmount(t, "1.23", "CHF"), step: mustNewAmount(t, "0", "CHF"), err: ErrNonPositiveStep},
+		"negative step":         {amount: mustNewAmount(t, "1.23", "CHF"), step: fiveCents.Neg(), err: ErrNonPositiveStep},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.amount.RoundToNearest(tc.step)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+			if cmp, _ := got.Compare(tc.want); cmp != 0 || got.currency != tc.want.currency {
+				t.Errorf("RoundToNearest() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAmount_Percentage(t *testing.T) {
+	tt := map[string]struct {
+		amount Amount
+		pct    int
+		want   Amount
+		err    error
+	}{
+		"20% of 100.00":     {amount: mustNewAmount(t, "100.00", "EUR"), pct: 20, want: mustNewAmount(t, "20.00", "EUR")},
+		"half rounded up":   {amount: mustNewAmount(t, "0.10", "EUR"), pct: 15, want: mustNewAmount(t, "0.02", "EUR")},
+		"rounded up":        {amount: mustNewAmount(t, "19.99", "EUR"), pct: 7, want: mustNewAmount(t, "1.40", "EUR")},
+		"rounded down":      {amount: mustNewAmount(t, "10.01", "EUR"), pct: 3, want: mustNewAmount(t, "0.30", "EUR")},
+		"no decimals":       {amount: mustNewAmount(t, "1235", "IRR"), pct: 10, want: mustNewAmount(t, "124", "IRR")},
+		"negative amount":   {amount: mustNewAmount(t, "-0.10", "EUR"), pct: 15, want: mustNewAmount(t, "-0.02", "EUR")},
+		"zero percent":      {amount: mustNewAmount(t, "19.99", "EUR"), pct: 0, want: mustNewAmount(t, "0", "EUR")},
+		"above 100 percent": {amount: mustNewAmount(t, "10.00", "EUR"), pct: 250, want: mustNewAmount(t, "25.00", "EUR")},
+		"negative percent":  {amount: mustNewAmount(t, "100.00", "EUR"), pct: -20, err: ErrNegativePercentage},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.amount.Percentage(tc.pct)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+			if got != tc.want || got.String() != tc.want.String() {
+				t.Errorf("Percentage() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMaxMin(t *testing.T) {
+	tt := map[string]struct {
+		amounts []Amount
+		max     Amount
+		min     Amount
+		err     error
+	}{
+		"clear winner": {
+			amounts: []Amount{mustNewAmount(t, "3", "EUR"), mustNewAmount(t, "12.5", "EUR"), mustNewAmount(t, "-1", "EUR")},
+			max:     mustNewAmount(t, "12.5", "EUR"),
+			min:     mustNewAmount(t, "-1", "EUR"),
+		},
+		"single amount": {
+			amounts: []Amount{mustNewAmount(t, "3", "EUR")},
+			max:     mustNewAmount(t, "3", "EUR"),
+			min:     mustNewAmount(t, "3", "EUR"),
+		},
+		"mismatched currencies": {
+			amounts: []Amount{mustNewAmount(t, "3", "EUR"), mustNewAmount(t, "3", "USD")},
+			err:     ErrCurrencyMismatch,
+		},
+		"empty input": {
+			amounts: nil,
+			err:     ErrNoAmounts,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			gotMax, err := Max(tc.amounts...)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Max() expected error %v, got %v", tc.err, err)
+			}
+			if gotMax != tc.max {
+				t.Errorf("Max() = %v, want %v", gotMax, tc.max)
+			}
+
+			gotMin, err := Min(tc.amounts...)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Min() expected error %v, got %v", tc.err, err)
+			}
+			if gotMin != tc.min {
+				t.Errorf("Min() = %v, want %v", gotMin, tc.min)
+			}
+		})
+	}
+
+	t.Run("tie returns the first", func(t *testing.T) {
+		// Both amounts are worth 2 EUR, but they are stored with different precisions,
+		// which lets us tell which one was returned.
+		first := Amount{quantity: Decimal{subunits: 2, precision: 0}, currency: Currency{code: "EUR", precision: 2}}
+		second := mustNewAmount(t, "2", "EUR")
+
+		gotMax, err := Max(first, second)
+		if err != nil || gotMax != first {
+			t.Errorf("Max() = %v, %v, want the first amount %v", gotMax, err, first)
+		}
+
+		gotMin, err := Min(first, second)
+		if err != nil || gotMin != first {
+			t.Errorf("Min() = %v, %v, want the first amount %v", gotMin, err, first)
+		}
+	})
+}
+
 // Helper functions (can be defined in a _test.go file or a separate test utility file)
 
 func mustParseCurrency(t *testing.T, code string) Currency {
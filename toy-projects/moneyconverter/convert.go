@@ -34,6 +34,24 @@ func Convert(amount Amount, to Currency, rates ratesFetcher) (Amount, error) {
 	return convertedValue, nil
 }
 
+// ConvertVia converts an Amount to the target currency through an intermediate one, e.g. from RON to JPY via EUR,
+// when rates doesn't know the direct rate. It's two successive calls to Convert: amount to via, then via to to.
+// The intermediate amount is truncated to the precision of the via currency, like any converted amount,
+// and the result has the precision of the target currency.
+func ConvertVia(amount Amount, via, to Currency, rates ratesFetcher) (Amount, error) {
+	intermediate, err := Convert(amount, via, rates)
+	if err != nil {
+		return Amount{}, fmt.Errorf("failed to convert to intermediate currency %s: %w", via.Code(), err)
+	}
+
+	converted, err := Convert(intermediate, to, rates)
+	if err != nil {
+		return Amount{}, fmt.Errorf("failed to convert from intermediate currency %s: %w", via.Code(), err)
+	}
+
+	return converted, nil
+}
+
 // ratesFetcher is an interface that defines a method for fetching exchange rates.
 // This abstraction allows the Convert function to be independent of how rates are obtained.
 // For example, one implementation might call a web service, while another might read from a local cache or a mock for tests.
@@ -75,6 +75,81 @@ func TestConvert(t *testing.T) {
 	}
 }
 
+// TestConvertVia tests converting through an intermediate currency, with a different rate for each pair.
+func TestConvertVia(t *testing.T) {
+	rates := pairRateFetcher{
+		"RON->EUR": "0.2",
+		"EUR->USD": "1.0876",
+		"EUR->IRR": "45678.9",
+	}
+
+	tt := map[string]struct {
+		amount      money.Amount
+		via, to     money.Currency
+		expected    money.Amount
+		expectedErr error
+	}{
+		"RON to USD via EUR": {
+			amount:   mustNewAmount(t, "100.00", "RON"),
+			via:      mustParseCurrency(t, "EUR"),
+			to:       mustParseCurrency(t, "USD"),
+			expected: mustNewAmount(t, "21.75", "USD"), // 100.00 * 0.2 = 20.00 EUR, * 1.0876 = 21.752 USD, truncated.
+		},
+		"intermediate and target precision": {
+			amount: mustNewAmount(t, "12.34", "RON"),
+			via:    mustParseCurrency(t, "EUR"),
+			to:     mustParseCurrency(t, "IRR"),
+			// 12.34 * 0.2 = 2.468 EUR, truncated to 2.46 EUR. * 45678.9 = 112370.094 IRR, truncated to 112370 IRR.
+			expected: mustNewAmount(t, "112370", "IRR"),
+		},
+		"missing first rate": {
+			amount:      mustNewAmount(t, "100.00", "USD"),
+			via:         mustParseCurrency(t, "EUR"),
+			to:          mustParseCurrency(t, "RON"),
+			expectedErr: errUnknownPair,
+		},
+		"missing second rate": {
+			amount:      mustNewAmount(t, "100.00", "RON"),
+			via:         mustParseCurrency(t, "EUR"),
+			to:          mustParseCurrency(t, "GBP"),
+			expectedErr: errUnknownPair,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			got, err := money.ConvertVia(tc.amount, tc.via, tc.to, rates)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+// errUnknownPair is returned by pairRateFetcher for the pairs it doesn't know.
+var errUnknownPair = errors.New("unknown currency pair")
+
+// pairRateFetcher is a stub implementation of the ratesFetcher interface, with a rate per currency pair.
+// Its keys are pairs such as "EUR->USD", its values are rates.
+type pairRateFetcher map[string]string
+
+// FetchExchangeRate implements the ratesFetcher interface for pairRateFetcher.
+func (p pairRateFetcher) FetchExchangeRate(source, target money.Currency) (money.ExchangeRate, error) {
+	rate, ok := p[source.Code()+"->"+target.Code()]
+	if !ok {
+		return money.ExchangeRate{}, fmt.Errorf("%w: %s to %s", errUnknownPair, source.Code(), target.Code())
+	}
+
+	decimal, err := money.ParseDecimal(rate)
+	if err != nil {
+		return money.ExchangeRate{}, err
+	}
+	return money.ExchangeRate(decimal), nil
+}
+
 // stubRateFetcher is a simple stub implementation of the ratesFetcher interface,
 // used for testing the Convert function without making real network calls.
 type stubRateFetcher struct {
@@ -53,3 +53,10 @@ func (c Currency) String() string {
 func (c Currency) Code() string {
 	return c.code
 }
+
+// CanRepresent tells whether the decimal fits the currency, i.e. whether NewAmount would accept it:
+// its precision mustn't exceed the currency's. For example, USD can represent 1.25 but not 1.255.
+// Decimals are simplified when they're parsed, so 1.250 has a precision of 2, and fits USD too.
+func (c Currency) CanRepresent(d Decimal) bool {
+	return d.precision <= c.precision
+}
@@ -57,3 +57,46 @@ func TestCurrency_StringAndCode(t *testing.T) {
 		t.Errorf("Currency.Code() = %q, want %q", c.Code(), "XYZ")
 	}
 }
+
+func TestCurrency_CanRepresent(t *testing.T) {
+	usd := Currency{code: "USD", precision: 2}
+	eur := Currency{code: "EUR", precision: 2}
+	jpy := Currency{code: "JPY", precision: 0}
+	irr := Currency{code: "IRR", precision: 0}
+
+	testCases := map[string]struct {
+		currency Currency
+		decimal  string
+		want     bool
+	}{
+		"USD, whole":               {currency: usd, decimal: "12", want: true},
+		"USD, cents":               {currency: usd, decimal: "1.25", want: true},
+		"USD, trailing zeros":      {currency: usd, decimal: "1.250", want: true},
+		"USD, too precise":         {currency: usd, decimal: "1.255", want: false},
+		"EUR, cents":               {currency: eur, decimal: "150.25", want: true},
+		"EUR, too precise":         {currency: eur, decimal: "150.255", want: false},
+		"JPY, whole":               {currency: jpy, decimal: "150", want: true},
+		"JPY, half a yen":          {currency: jpy, decimal: "0.5", want: false},
+		"IRR, whole":               {currency: irr, decimal: "1500", want: true},
+		"IRR, no decimals allowed": {currency: irr, decimal: "1500.5", want: false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			d, err := ParseDecimal(tc.decimal)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := tc.currency.CanRepresent(d); got != tc.want {
+				t.Errorf("CanRepresent(%s) = %t, want %t", tc.decimal, got, tc.want)
+			}
+
+			// CanRepresent must agree with NewAmount.
+			_, err = NewAmount(d, tc.currency)
+			if accepted := err == nil; accepted != tc.want {
+				t.Errorf("NewAmount(%s) accepted = %t, but CanRepresent = %t", tc.decimal, accepted, tc.want)
+			}
+		})
+	}
+}
@@ -58,13 +58,19 @@ func (d *Decimal) String() string {
 		return fmt.Sprintf("%d", d.subunits)
 	}
 
+	// The sign is printed on its own, otherwise both the integer and the fractional parts would carry it.
+	sign, subunits := "", d.subunits
+	if subunits < 0 {
+		sign, subunits = "-", -subunits
+	}
+
 	centsPerUnit := pow10(d.precision)
-	frac := d.subunits % centsPerUnit
-	integer := d.subunits / centsPerUnit
+	frac := subunits % centsPerUnit
+	integer := subunits / centsPerUnit
 
 	// We always want to print the correct number of digits - even if they finish with 0.
-	decimalFormat := "%d.%0" + strconv.Itoa(int(d.precision)) + "d"
-	return fmt.Sprintf(decimalFormat, integer, frac)
+	decimalFormat := "%s%d.%0" + strconv.Itoa(int(d.precision)) + "d"
+	return fmt.Sprintf(decimalFormat, sign, integer, frac)
 }
 
 // pow10 is a quick implementation of how to raise 10 to a given power.
@@ -121,6 +121,9 @@ func TestDecimal_String(t *testing.T) {
 		{"three decimal places", Decimal{subunits: 12305, precision: 3}, "12.305"},
 		{"zero value", Decimal{subunits: 0, precision: 0}, "0"},
 		{"zero with precision", Decimal{subunits: 0, precision: 2}, "0.00"}, // e.g. from 0.00
+		{"negative integer", Decimal{subunits: -123, precision: 0}, "-123"},
+		{"negative two decimal places", Decimal{subunits: -12345, precision: 2}, "-123.45"},
+		{"negative below one", Decimal{subunits: -5, precision: 2}, "-0.05"},
 	}
 
 	for _, tc := range testCases {
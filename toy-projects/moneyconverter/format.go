@@ -0,0 +1,59 @@
+package money
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Format implements the fmt.Formatter interface for the Amount type, so that amounts print nicely
+// with the fmt and log packages.
+//   - %v and %s print the amount and its currency, like String: "19.99 USD".
+//   - %q prints the amount and its currency as a double-quoted Go string, like %q does with String: `"19.99 USD"`.
+//   - %f prints the quantity only: "19.99".
+//
+// A precision truncates the quantity to that many decimal places, without rounding: %.0v prints "19 USD".
+// A width pads the result with spaces, on the left by default, on the right with the '-' flag: %10v, %-10v.
+// Any other verb is reported as a bad verb, the way fmt does it: %!d(money.Amount=19.99 USD).
+func (a Amount) Format(f fmt.State, verb rune) {
+	quantity := a.quantity
+	if places, ok := f.Precision(); ok {
+		quantity = quantity.truncate(places)
+	}
+
+	var text string
+	switch verb {
+	case 'v', 's':
+		text = quantity.String() + " " + a.currency.Code()
+	case 'q':
+		text = strconv.Quote(quantity.String() + " " + a.currency.Code())
+	case 'f':
+		text = quantity.String()
+	default:
+		_, _ = fmt.Fprintf(f, "%%!%c(money.Amount=%s)", verb, a.String())
+		return
+	}
+
+	if width, ok := f.Width(); ok && width > len(text) {
+		padding := strings.Repeat(" ", width-len(text))
+		if f.Flag('-') {
+			text += padding
+		} else {
+			text = padding + text
+		}
+	}
+
+	_, _ = fmt.Fprint(f, text)
+}
+
+// truncate returns the decimal with at most the given number of decimal places.
+// Extra digits are dropped, rounding towards zero: 1.99 truncated to 0 places is 1.
+func (d Decimal) truncate(places int) Decimal {
+	if places < 0 || places >= int(d.precision) {
+		return d
+	}
+
+	d.subunits /= pow10(d.precision - byte(places))
+	d.precision = byte(places)
+	return d
+}
@@ -0,0 +1,42 @@
+// Package money (continued) - this file lets amounts be stored in and read from SQL databases.
+package money
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Value implements the driver.Valuer interface: an Amount is stored as its string representation, e.g. "19.99 USD".
+// The zero Amount, which has no currency, is stored as NULL, the value Scan reads it from.
+func (a Amount) Value() (driver.Value, error) {
+	if a.currency == (Currency{}) {
+		return nil, nil
+	}
+	return a.String(), nil
+}
+
+// Scan implements the sql.Scanner interface: it reads an Amount stored as a string, e.g. "19.99 USD".
+// A NULL value results in the zero Amount.
+func (a *Amount) Scan(src any) error {
+	var value string
+	switch v := src.(type) {
+	case nil:
+		// NULL in the database.
+		*a = Amount{}
+		return nil
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return fmt.Errorf("cannot scan a %T into an Amount", src)
+	}
+
+	amount, err := ParseAmount(value)
+	if err != nil {
+		return fmt.Errorf("cannot scan %q into an Amount: %w", value, err)
+	}
+
+	*a = amount
+	return nil
+}
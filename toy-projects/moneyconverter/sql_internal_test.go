@@ -0,0 +1,102 @@
+// Package money_test contains internal tests for the money package.
+package money
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+)
+
+// The compiler checks that Amount implements the interfaces of the database/sql package.
+var (
+	_ sql.Scanner   = (*Amount)(nil)
+	_ driver.Valuer = Amount{}
+)
+
+func TestAmount_ValueScan(t *testing.T) {
+	amounts := map[string]Amount{
+		"2 decimal digits": mustNewAmount(t, "19.99", "USD"),
+		"no decimal digit": mustNewAmount(t, "1500", "IRR"),
+		"3 decimal digits": mustNewAmount(t, "1.234", "BHD"),
+		"negative amount":  mustNewAmount(t, "-3.50", "EUR"),
+	}
+
+	for name, amount := range amounts {
+		t.Run(name, func(t *testing.T) {
+			value, err := amount.Value()
+			if err != nil {
+				t.Fatalf("Value() returned an unexpected error: %v", err)
+			}
+
+			var got Amount
+			if err = got.Scan(value); err != nil {
+				t.Fatalf("Scan(%v) returned an unexpected error: %v", value, err)
+			}
+			if got != amount {
+				t.Errorf("round trip: expected %v, got %v", amount, got)
+			}
+
+			// Drivers may also return text columns as bytes.
+			var gotFromBytes Amount
+			if err = gotFromBytes.Scan([]byte(value.(string))); err != nil {
+				t.Fatalf("Scan([]byte(%v)) returned an unexpected error: %v", value, err)
+			}
+			if gotFromBytes != amount {
+				t.Errorf("round trip from bytes: expected %v, got %v", amount, gotFromBytes)
+			}
+		})
+	}
+}
+
+func TestAmount_ScanNull(t *testing.T) {
+	got := mustNewAmount(t, "19.99", "USD")
+	if err := got.Scan(nil); err != nil {
+		t.Fatalf("Scan(nil) returned an unexpected error: %v", err)
+	}
+	if got != (Amount{}) {
+		t.Errorf("Scan(nil): expected the zero Amount, got %v", got)
+	}
+
+	// NULL → Scan → Value → Scan: the zero Amount is written back as NULL, and read again.
+	value, err := got.Value()
+	if err != nil {
+		t.Fatalf("Value() returned an unexpected error: %v", err)
+	}
+	if value != nil {
+		t.Fatalf("Value(): expected NULL for the zero Amount, got %q", value)
+	}
+	var again Amount
+	if err := again.Scan(value); err != nil {
+		t.Fatalf("Scan(%v) returned an unexpected error: %v", value, err)
+	}
+	if again != (Amount{}) {
+		t.Errorf("round trip: expected the zero Amount, got %v", again)
+	}
+}
+
+func TestAmount_ScanInvalid(t *testing.T) {
+	tt := map[string]struct {
+		src any
+		err error
+	}{
+		"no currency":      {src: "19.99", err: ErrInvalidAmount},
+		"invalid decimal":  {src: "nineteen USD", err: ErrInvalidDecimal},
+		"invalid currency": {src: "19.99 DOLLARS", err: ErrInvalidCurrencyCode},
+		"too precise":      {src: "19.999 USD", err: ErrTooPrecise},
+		"unsupported type": {src: 19.99, err: nil},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			var got Amount
+			err := got.Scan(tc.src)
+			if err == nil {
+				t.Fatalf("Scan(%v): expected an error, got nil", tc.src)
+			}
+			if tc.err != nil && !errors.Is(err, tc.err) {
+				t.Errorf("Scan(%v): expected error %v, got %v", tc.src, tc.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// Package money (continued) - this file spells amounts out in words, e.g. for check printing.
+package money
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Predefined errors for amounts that can't be spelled out.
+const (
+	// ErrNoWordsForCurrency is returned when an amount's currency isn't in the table of unit names.
+	ErrNoWordsForCurrency = MoneyError("no words known for this currency")
+
+	// ErrNoMinorUnit is returned when an amount has a fractional part in a currency that has no minor unit in words,
+	// e.g. 1.50 JPY.
+	ErrNoMinorUnit = MoneyError("amount has a fractional part, but its currency has no minor unit")
+)
+
+// unitNames are the singular and plural names of a currency's units.
+type unitNames struct {
+	singular, plural string
+}
+
+// currencyWords holds the names of a currency's main unit, and of its minor unit, if it has one.
+type currencyWords struct {
+	major unitNames
+	minor unitNames // minor is empty for currencies whose amounts are always whole, like the yen.
+}
+
+// wordsByCurrency is the table of the currencies Words can spell out.
+var wordsByCurrency = map[string]currencyWords{
+	"USD": {major: unitNames{"dollar", "dollars"}, minor: unitNames{"cent", "cents"}},
+	"CAD": {major: unitNames{"dollar", "dollars"}, minor: unitNames{"cent", "cents"}},
+	"EUR": {major: unitNames{"euro", "euros"}, minor: unitNames{"cent", "cents"}},
+	"GBP": {major: unitNames{"pound", "pounds"}, minor: unitNames{"penny", "pence"}},
+	"CHF": {major: unitNames{"franc", "francs"}, minor: unitNames{"centime", "centimes"}},
+	"JPY": {major: unitNames{"yen", "yen"}},
+}
+
+// Words spells the amount out in English, as written on a check: 19.99 USD is "nineteen dollars and ninety-nine cents".
+// Whole amounts leave out the minor unit (1.00 USD is "one dollar"), and amounts below one unit
+// leave out the main unit (0.05 EUR is "five cents"). Zero is "zero dollars". Negative amounts start with "minus".
+// It returns ErrNoWordsForCurrency if the currency isn't in its table (USD, CAD, EUR, GBP, CHF and JPY),
+// and ErrNoMinorUnit for an amount with a fractional part in a currency without minor unit, like 1.50 JPY.
+func (a Amount) Words() (string, error) {
+	words, ok := wordsByCurrency[a.currency.code]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrNoWordsForCurrency, a.currency.code)
+	}
+
+	// The quantity is brought to the currency's precision, so that its subunits count minor units, e.g. cents.
+	aligned, err := a.WithPrecision(a.currency.precision)
+	if err != nil {
+		return "", err
+	}
+	subunits := aligned.quantity.subunits
+
+	sign := ""
+	if subunits < 0 {
+		sign, subunits = "minus ", -subunits
+	}
+	major, minor := subunits/pow10(a.currency.precision), subunits%pow10(a.currency.precision)
+
+	if words.minor.singular == "" {
+		if minor != 0 {
+			return "", fmt.Errorf("%w: %s", ErrNoMinorUnit, a.String())
+		}
+		return sign + spellCount(major, words.major), nil
+	}
+
+	switch {
+	case minor == 0:
+		return sign + spellCount(major, words.major), nil
+	case major == 0:
+		return sign + spellCount(minor, words.minor), nil
+	default:
+		return sign + spellCount(major, words.major) + " and " + spellCount(minor, words.minor), nil
+	}
+}
+
+// spellCount spells out n followed by the name of the unit, singular or plural: "one dollar", "two dollars".
+func spellCount(n int64, names unitNames) string {
+	if n == 1 {
+		return "one " + names.singular
+	}
+	return spellNumber(n) + " " + names.plural
+}
+
+var (
+	// smallNumbers are the names of the numbers below twenty.
+	smallNumbers = []string{
+		"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen",
+	}
+	// tens are the names of the multiples of ten, indexed by their number of tens.
+	tens = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+)
+
+// scales are the names of the large powers of a thousand, largest first.
+// Amounts are capped at maxDecimal, so billions are enough to spell any of them.
+var scales = []struct {
+	value int64
+	name  string
+}{
+	{1_000_000_000, "billion"},
+	{1_000_000, "million"},
+	{1_000, "thousand"},
+}
+
+// spellNumber spells out a non-negative number in English words: 1234 is "one thousand two hundred thirty-four".
+func spellNumber(n int64) string {
+	if n == 0 {
+		return "zero"
+	}
+
+	var parts []string
+	for _, scale := range scales {
+		if n >= scale.value {
+			parts = append(parts, spellBelowThousand(n/scale.value)+" "+scale.name)
+			n %= scale.value
+		}
+	}
+	if n > 0 {
+		parts = append(parts, spellBelowThousand(n))
+	}
+	return strings.Join(parts, " ")
+}
+
+// spellBelowThousand spells out a number between 1 and 999: 342 is "three hundred forty-two".
+func spellBelowThousand(n int64) string {
+	var parts []string
+	if n >= 100 {
+		parts = append(parts, smallNumbers[n/100]+" hundred")
+		n %= 100
+	}
+
+	switch {
+	case n == 0:
+	case n < 20:
+		parts = append(parts, smallNumbers[n])
+	case n%10 == 0:
+		parts = append(parts, tens[n/10])
+	default:
+		parts = append(parts, tens[n/10]+"-"+smallNumbers[n%10])
+	}
+	return strings.Join(parts, " ")
+}
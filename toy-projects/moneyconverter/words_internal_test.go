@@ -0,0 +1,60 @@
+package money
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAmount_Words(t *testing.T) {
+	tt := map[string]struct {
+		amount Amount
+		want   string
+		err    error
+	}{
+		"dollars and cents":     {amount: mustNewAmount(t, "19.99", "USD"), want: "nineteen dollars and ninety-nine cents"},
+		"exactly one dollar":    {amount: mustNewAmount(t, "1.00", "USD"), want: "one dollar"},
+		"one cent":              {amount: mustNewAmount(t, "0.01", "USD"), want: "one cent"},
+		"zero":                  {amount: mustNewAmount(t, "0", "USD"), want: "zero dollars"},
+		"one and one":           {amount: mustNewAmount(t, "1.01", "EUR"), want: "one euro and one cent"},
+		"less precise":          {amount: mustNewAmount(t, "2.5", "GBP"), want: "two pounds and fifty pence"},
+		"large":                 {amount: mustNewAmount(t, "1234567.08", "USD"), want: "one million two hundred thirty-four thousand five hundred sixty-seven dollars and eight cents"},
+		"round hundreds":        {amount: mustNewAmount(t, "300", "CHF"), want: "three hundred francs"},
+		"negative":              {amount: mustNewAmount(t, "-5.10", "EUR"), want: "minus five euros and ten cents"},
+		"yen":                   {amount: mustNewAmount(t, "1500", "JPY"), want: "one thousand five hundred yen"},
+		"one yen":               {amount: mustNewAmount(t, "1", "JPY"), want: "one yen"},
+		"fractional yen":        {amount: mustNewAmount(t, "1.50", "JPY"), err: ErrNoMinorUnit},
+		"currency not in table": {amount: mustNewAmount(t, "10.00", "RON"), err: ErrNoWordsForCurrency},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.amount.Words()
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+			if got != tc.want {
+				t.Errorf("Words() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSpellNumber(t *testing.T) {
+	tt := map[int64]string{
+		0:             "zero",
+		7:             "seven",
+		13:            "thirteen",
+		40:            "forty",
+		99:            "ninety-nine",
+		101:           "one hundred one",
+		1000:          "one thousand",
+		1_000_001:     "one million one",
+		2_500_000_000: "two billion five hundred million",
+	}
+
+	for n, want := range tt {
+		if got := spellNumber(n); got != want {
+			t.Errorf("spellNumber(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package pikalog
+
+// Close shuts the logger down, e.g. when the program stops: the messages logged afterwards are dropped
+// instead of being written to an output that may be closed already. They're counted, see DroppedCount.
+// Close doesn't close the output nor the sink, which belong to the caller. Closing a closed logger does nothing.
+// It's safe to call while other goroutines are logging: a message logged at the same time may still be written.
+// A logger and the loggers derived from it with With are closed together, whichever of them is closed.
+// With WithDedup, the summary of the repeats suppressed so far is written before the logger closes.
+func (l *Logger) Close() error {
+	if l.state.closed.Load() {
+		return nil
+	}
+
+	// Closing is the end of the current dedup window: its summary would be lost otherwise.
+	if l.dedup != nil {
+		for _, e := range l.dedup.flush(l.now()) {
+			l.write(e)
+		}
+	}
+
+	l.state.closed.Store(true)
+	return nil
+}
+
+// DroppedCount returns the number of messages that were dropped because they were logged after Close.
+// Messages below the threshold aren't counted: they would have been skipped anyway.
+func (l *Logger) DroppedCount() uint64 {
+	return l.state.dropped.Load()
+}
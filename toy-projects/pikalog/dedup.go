@@ -0,0 +1,62 @@
+package pikalog
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// deduper suppresses identical entries (same level and message) logged within a time window.
+// It has no timer of its own: a closed window is only noticed when the next entry arrives,
+// which is when the "(repeated N times)" summary is written.
+type deduper struct {
+	window time.Duration
+
+	mu      sync.Mutex
+	last    Entry     // last is the entry that opened the current window.
+	opened  time.Time // opened is when the current window started. Zero means there's no window yet.
+	repeats int       // repeats counts the entries suppressed in the current window.
+}
+
+// filter returns the entries that should actually be written for the given entry:
+// none if it's a repeat within the window, otherwise the pending summary (if any) followed by the entry itself.
+func (d *deduper) filter(entry Entry) []Entry {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	sameAsLast := !d.opened.IsZero() && entry.Level == d.last.Level && entry.Message == d.last.Message
+	if sameAsLast && entry.Time.Sub(d.opened) < d.window {
+		d.repeats++
+		return nil
+	}
+
+	entries := d.summary(entry.Time)
+
+	// The new entry opens a new window.
+	d.last, d.opened, d.repeats = entry, entry.Time, 0
+	return append(entries, entry)
+}
+
+// flush closes the current window, e.g. when the logger is closed, and returns its pending summary, if any.
+func (d *deduper) flush(now time.Time) []Entry {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	entries := d.summary(now)
+	d.last, d.opened, d.repeats = Entry{}, time.Time{}, 0
+	return entries
+}
+
+// summary returns the "(repeated N times)" entry of the current window, dated now, or nothing if there were no repeats.
+// The caller must hold the lock.
+func (d *deduper) summary(now time.Time) []Entry {
+	if d.repeats == 0 {
+		return nil
+	}
+	return []Entry{{
+		Level:   d.last.Level,
+		Time:    now,
+		Message: fmt.Sprintf("%s (repeated %d times)", d.last.Message, d.repeats),
+		Fields:  d.last.Fields,
+	}}
+}
@@ -0,0 +1,40 @@
+package pikalog
+
+// HighestLevel returns the level of the most severe message this logger wrote so far,
+// e.g. to decide how the program should exit, see SuggestedExitCode.
+// Messages below the threshold, or dropped after Close, aren't counted: they weren't written.
+// A logger that hasn't written anything yet returns LevelDebug.
+// The messages of the loggers derived with With count too: they share their highest level.
+// It's safe to call while other goroutines are logging.
+func (l *Logger) HighestLevel() Level {
+	return Level(l.state.highest.Load())
+}
+
+// SuggestedExitCode maps the highest level written by the logger (see HighestLevel) to a process exit code:
+//   - 0 if nothing worse than Info was logged, the program went fine;
+//   - 1 if an error was logged, the usual code of a failed program;
+//   - 2 if a warning, but no error, was logged, so scripts can tell "finished with warnings" apart from failures.
+//
+// A typical use is `os.Exit(logger.SuggestedExitCode())` at the end of main.
+func (l *Logger) SuggestedExitCode() int {
+	switch l.HighestLevel() {
+	case LevelError:
+		return 1
+	case LevelWarn:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// recordLevel remembers lvl if it's the most severe level written so far.
+// Several goroutines may log at once: the maximum is updated with a compare-and-swap loop,
+// which retries if another goroutine changed the value between the read and the update.
+func (l *Logger) recordLevel(lvl Level) {
+	for {
+		highest := l.state.highest.Load()
+		if uint32(lvl) <= highest || l.state.highest.CompareAndSwap(highest, uint32(lvl)) {
+			return
+		}
+	}
+}
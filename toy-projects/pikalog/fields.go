@@ -0,0 +1,75 @@
+package pikalog
+
+import (
+	"maps"
+	"time"
+)
+
+// Field is a named value added to log messages, see With.
+// The helpers, such as Duration and Err, build fields whose values are written the same way everywhere.
+type Field struct {
+	Key   string // Key is the name of the field in the JSON messages.
+	Value any    // Value is written as JSON, as encoding/json would write it.
+}
+
+// With returns a logger that adds the given fields to every message it writes, e.g. a request ID
+// for all the messages about a request. The original logger is left unchanged.
+// The new logger shares everything else with the original: configuration, output, Close and HighestLevel.
+// When a message has a field of the same name, e.g. from the context, the message's field is written.
+func (l *Logger) With(fields ...Field) *Logger {
+	// Copying the logger is cheap, and safe: what must be shared is behind pointers.
+	derived := *l
+	derived.fields = make(map[string]any, len(l.fields)+len(fields))
+	maps.Copy(derived.fields, l.fields)
+	for _, f := range fields {
+		derived.fields[f.Key] = f.Value
+	}
+	return &derived
+}
+
+// Duration returns a field holding d as a number of milliseconds, with decimals if needed:
+// 1.5s is written 1500, and 250µs is written 0.25. A number is easier to sort and aggregate than d.String().
+func Duration(key string, d time.Duration) Field {
+	return Field{Key: key, Value: float64(d) / float64(time.Millisecond)}
+}
+
+// Err returns a field named "error", holding the message of err and the messages of the errors it wraps:
+//
+//	"error":{"chain":["open config.json: no such file or directory","no such file or directory"],"message":"loading: open config.json: no such file or directory"}
+//
+// The chain is empty if err doesn't wrap anything. It follows errors.Unwrap, and errors.Join, in depth-first order.
+// A nil err gives a null value.
+func Err(err error) Field {
+	if err == nil {
+		return Field{Key: "error", Value: nil}
+	}
+	return Field{Key: "error", Value: map[string]any{
+		"message": err.Error(),
+		"chain":   unwrapChain(err),
+	}}
+}
+
+// unwrapChain returns the messages of the errors wrapped by err, in depth-first order, err excluded.
+// The chain is never nil, so that it's written as [] rather than null.
+func unwrapChain(err error) []string {
+	chain := []string{}
+	var walk func(error)
+	walk = func(e error) {
+		var wrapped []error
+		switch u := e.(type) {
+		case interface{ Unwrap() error }:
+			wrapped = []error{u.Unwrap()}
+		case interface{ Unwrap() []error }:
+			wrapped = u.Unwrap()
+		}
+		for _, w := range wrapped {
+			if w == nil {
+				continue
+			}
+			chain = append(chain, w.Error())
+			walk(w)
+		}
+	}
+	walk(err)
+	return chain
+}
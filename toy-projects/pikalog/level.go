@@ -1,5 +1,11 @@
 package pikalog
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
 // Level is a custom type representing the severity of a log message.
 // We use `byte` as the underlying type because there are few levels,
 // making it memory-efficient.
@@ -15,8 +21,11 @@ const (
 	// LevelInfo represents a logging level that contains information deemed valuable.
 	// iota will be 1 here.
 	LevelInfo
-	// LevelError represents the highest logging level, only to be used to trace errors.
+	// LevelWarn represents a logging level for unexpected situations that the program can recover from.
 	// iota will be 2 here.
+	LevelWarn
+	// LevelError represents the highest logging level, only to be used to trace errors.
+	// iota will be 3 here.
 	LevelError
 )
 
@@ -29,6 +38,9 @@ func (lvl Level) String() string {
 	case LevelInfo:
 		// Returns a human-readable string for the Info level.
 		return "[INFO]"
+	case LevelWarn:
+		// Returns a human-readable string for the Warn level.
+		return "[WARN]"
 	case LevelError:
 		// Returns a human-readable string for the Error level.
 		return "[ERROR]"
@@ -40,3 +52,28 @@ func (lvl Level) String() string {
 		return ""
 	}
 }
+
+// ErrUnknownLevel is returned by ParseLevel when a text doesn't name a level.
+var ErrUnknownLevel = errors.New("unknown log level")
+
+// ParseLevel returns the level named by s, e.g. from a configuration file or an environment variable.
+// It accepts the names of the levels in any case, with or without the brackets of their String form:
+// "debug", "INFO", "[WARN]"... "warning" is accepted too, for Warn.
+// It returns an error wrapping ErrUnknownLevel if s doesn't name a level.
+func ParseLevel(s string) (Level, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	name = strings.TrimSuffix(strings.TrimPrefix(name, "["), "]")
+
+	switch name {
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownLevel, s)
+	}
+}
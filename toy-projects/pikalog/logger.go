@@ -1,18 +1,40 @@
 package pikalog
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"io"
+	"maps"
 	"os"
+	"sync/atomic"
+	"time"
 )
 
 // Logger is a struct that holds the configuration for our logger.
 // It's responsible for formatting and writing log messages.
 type Logger struct {
-	threshold        Level     // threshold is the minimum level of messages that this logger will output.
-	output           io.Writer // output is where the log messages will be written (e.g., console, file).
-	maxMessageLength uint      // maxMessageLength is the maximum number of characters for a single log message. 0 means no limit.
+	threshold        Level            // threshold is the minimum level of messages that this logger will output.
+	output           io.Writer        // output is where the log messages will be written (e.g., console, file).
+	maxMessageLength uint             // maxMessageLength is the maximum number of characters for a single log message. 0 means no limit.
+	contextKeys      []any            // contextKeys lists the keys of the context values that the ...Ctx methods add to messages.
+	timeFormat       string           // timeFormat is the layout of the messages' timestamp. Empty means no timestamp.
+	now              func() time.Time // now returns the current time, it can be replaced in tests.
+	sink             Sink             // sink receives the log entries. By default, it writes them as JSON to output.
+	dedup            *deduper         // dedup suppresses repeated messages. nil means every message is written.
+	prettyJSON       bool             // prettyJSON tells whether the default sink indents the JSON it writes.
+	stackTraces      bool             // stackTraces tells whether error messages get a "stack" field.
+	levelNames       map[Level]string // levelNames overrides the names of some levels in the output. It can be nil.
+	startupWarnings  []string         // startupWarnings are problems found by the options, written once the logger is ready.
+	fields           map[string]any   // fields are added to every message, see With. It can be nil.
+	state            *loggerState     // state is shared by the logger and the loggers derived from it with With.
+}
+
+// loggerState is what a logger shares with the loggers derived from it:
+// closing one of them closes them all, and they count their messages together.
+type loggerState struct {
+	closed  atomic.Bool   // closed tells whether Close was called. It's atomic, as any goroutine may log or close.
+	dropped atomic.Uint64 // dropped counts the messages logged after Close.
+	highest atomic.Uint32 // highest is the most severe level written so far, see HighestLevel.
 }
 
 // New returns you a logger, ready to log at the required threshold.
@@ -30,6 +52,9 @@ func New(threshold Level, opts ...Option) *Logger {
 		// Default maxMessageLength is 0, meaning messages are not trimmed by default.
 		// The comment below is good for learners, explaining the choice for explicitness.
 		maxMessageLength: 0, // we could get rid of this line and use the zero value but let's be explicit
+		// Messages aren't timestamped by default, but the clock is ready if they are.
+		now:   time.Now,
+		state: &loggerState{},
 	}
 
 	// Apply all a.k.a "functional options" passed by the caller.
@@ -39,11 +64,27 @@ func New(threshold Level, opts ...Option) *Logger {
 		configFunc(lgr)
 	}
 
+	// Without a custom sink, entries are written as JSON, using the configured output and time format.
+	if lgr.sink == nil {
+		lgr.sink = &jsonSink{output: lgr.output, timeFormat: lgr.timeFormat, pretty: lgr.prettyJSON, levelNames: lgr.levelNames}
+	}
+
+	// A misconfigured logger must say so even if its threshold is above warnings, so the level isn't checked.
+	// The warnings are written straight to the sink: they're about the configuration, not about the program,
+	// and mustn't count in HighestLevel, which decides the suggested exit code.
+	for _, warning := range lgr.startupWarnings {
+		lgr.write(Entry{Level: LevelWarn, Time: lgr.now(), Message: warning})
+	}
+
 	return lgr
 }
 
 // Debugf formats and prints a message if the logger's threshold is LevelDebug or lower.
 // It uses `fmt.Sprintf`-like formatting.
+// The threshold is checked before anything is formatted: a suppressed Debugf call doesn't pay for fmt.Sprintf,
+// so debug messages can be left in hot code paths (see BenchmarkLogger_Debugf).
+// It isn't entirely free though: the arguments are still put into the args slice before Debugf is called,
+// which allocates for arguments that aren't constants.
 func (l *Logger) Debugf(format string, args ...any) {
 	// Check if the logger's configured threshold allows Debug messages.
 	// For example, if threshold is LevelInfo, LevelDebug messages will be skipped.
@@ -51,7 +92,7 @@ func (l *Logger) Debugf(format string, args ...any) {
 		return
 	}
 	// Delegate the actual logging to the internal logf method.
-	l.logf(LevelDebug, format, args...)
+	l.logf(LevelDebug, nil, format, args...)
 }
 
 // Infof formats and prints a message if the logger's threshold is LevelInfo or lower.
@@ -62,7 +103,16 @@ func (l *Logger) Infof(format string, args ...any) {
 		return
 	}
 	// Delegate the actual logging to the internal logf method.
-	l.logf(LevelInfo, format, args...)
+	l.logf(LevelInfo, nil, format, args...)
+}
+
+// Warnf formats and prints a message if the logger's threshold is LevelWarn or lower.
+// It uses `fmt.Sprintf`-like formatting.
+func (l *Logger) Warnf(format string, args ...any) {
+	if l.threshold > LevelWarn {
+		return
+	}
+	l.logf(LevelWarn, nil, format, args...)
 }
 
 // Errorf formats and prints a message. Error messages are always logged unless the
@@ -76,7 +126,7 @@ func (l *Logger) Errorf(format string, args ...any) {
 		return
 	}
 	// Delegate the actual logging to the internal logf method.
-	l.logf(LevelError, format, args...)
+	l.logf(LevelError, nil, format, args...)
 }
 
 // Logf formats and prints a message if the provided `lvl` is at or above the logger's threshold.
@@ -87,14 +137,86 @@ func (l *Logger) Logf(lvl Level, format string, args ...any) {
 		return
 	}
 	// Delegate the actual logging to the internal logf method.
-	l.logf(lvl, format, args...)
+	l.logf(lvl, nil, format, args...)
+}
+
+// DebugCtx is like Debugf, but also adds the context values configured with WithContextKeys to the message.
+func (l *Logger) DebugCtx(ctx context.Context, format string, args ...any) {
+	l.LogCtx(ctx, LevelDebug, format, args...)
+}
+
+// InfoCtx is like Infof, but also adds the context values configured with WithContextKeys to the message.
+func (l *Logger) InfoCtx(ctx context.Context, format string, args ...any) {
+	l.LogCtx(ctx, LevelInfo, format, args...)
+}
+
+// WarnCtx is like Warnf, but also adds the context values configured with WithContextKeys to the message.
+func (l *Logger) WarnCtx(ctx context.Context, format string, args ...any) {
+	l.LogCtx(ctx, LevelWarn, format, args...)
+}
+
+// ErrorCtx is like Errorf, but also adds the context values configured with WithContextKeys to the message.
+func (l *Logger) ErrorCtx(ctx context.Context, format string, args ...any) {
+	l.LogCtx(ctx, LevelError, format, args...)
+}
+
+// LogCtx is like Logf, but also adds the context values configured with WithContextKeys to the message.
+// Keys that aren't set in the context are skipped.
+func (l *Logger) LogCtx(ctx context.Context, lvl Level, format string, args ...any) {
+	if l.threshold > lvl {
+		return
+	}
+	l.logf(lvl, l.contextFields(ctx), format, args...)
+}
+
+// contextFields extracts the values of the configured context keys.
+// Each value is named after its key, as printed by fmt.
+func (l *Logger) contextFields(ctx context.Context) map[string]any {
+	var fields map[string]any
+	for _, key := range l.contextKeys {
+		value := ctx.Value(key)
+		if value == nil {
+			continue
+		}
+		if fields == nil {
+			fields = make(map[string]any, len(l.contextKeys))
+		}
+		fields[fmt.Sprint(key)] = value
+	}
+	return fields
+}
+
+// mergeFields returns a new map holding the fields of base, then those of extra, which win when the keys are the same.
+// Copying leaves both maps untouched.
+func mergeFields(base, extra map[string]any) map[string]any {
+	merged := make(map[string]any, len(base)+len(extra))
+	maps.Copy(merged, base)
+	maps.Copy(merged, extra)
+	return merged
+}
+
+// withField returns a copy of the fields, with the given field added.
+// Copying leaves the caller's map untouched.
+func withField(fields map[string]any, key string, value any) map[string]any {
+	return mergeFields(fields, map[string]any{key: value})
 }
 
 // logf is an unexported (internal) method that handles the actual formatting and writing of the log message.
-// It's called by Debugf, Infof, Errorf, and Logf after they've checked the log level.
+// It's called by Debugf, Infof, Warnf, Errorf, Logf and their ...Ctx variants after they've checked the log level.
+// Callers must keep checking the level first: formatting the message is the expensive part of logging,
+// and it would be wasted on a message that isn't written.
 // `lvl` is the severity level of the current message.
+// `fields` are extra structured values to add to the message, it can be nil.
 // `format` and `args` are for `fmt.Sprintf`-style message formatting.
-func (l *Logger) logf(lvl Level, format string, args ...any) {
+func (l *Logger) logf(lvl Level, fields map[string]any, format string, args ...any) {
+	// After Close, the output may be gone: the message is dropped, and counted, before any work is done.
+	if l.state.closed.Load() {
+		l.state.dropped.Add(1)
+		return
+	}
+
+	l.recordLevel(lvl)
+
 	// Format the user-provided message string with its arguments.
 	contents := fmt.Sprintf(format, args...)
 
@@ -107,34 +229,37 @@ func (l *Logger) logf(lvl Level, format string, args ...any) {
 		contents = string([]rune(contents)[:l.maxMessageLength]) + "[TRIMMED]"
 	}
 
-	msg := message{
-		Level:   lvl.String(),
+	// The fields of the message win over the logger's fields of the same name.
+	if len(l.fields) != 0 {
+		fields = mergeFields(l.fields, fields)
+	}
+
+	// Capturing the stack is costly, it's only done for errors, and when asked for.
+	if l.stackTraces && lvl >= LevelError {
+		fields = withField(fields, "stack", stackTrace())
+	}
+
+	entry := Entry{
+		Level:   lvl,
+		Time:    l.now(),
 		Message: contents,
+		Fields:  fields,
 	}
 
-	// Encode the structured message (level + content) into JSON format.
-	// JSON is a common choice for structured logging as it's machine-readable
-	// and widely supported.
-	formattedMessage, err := json.Marshal(msg)
-	if err != nil {
-		// If JSON marshaling fails (which is rare for simple structs but possible),
-		// we fall back to printing a plain error message to the logger's output.
-		// This ensures that the logging attempt itself doesn't crash the application.
-		// The `_, _ = ...` is used to explicitly ignore the return values (bytes written, error)
-		// from Fprintf, as handling an error while handling another error can get complex.
-		_, _ = fmt.Fprintf(l.output, "unable to format message for %v\n", contents)
+	if l.dedup == nil {
+		l.write(entry)
 		return
 	}
 
-	// Write the JSON-formatted log message to the configured output (e.g., console).
-	// Fprintln adds a newline character at the end, which is typical for log entries.
-	// Again, we ignore the return values from Fprintln for simplicity in this example.
-	_, _ = fmt.Fprintln(l.output, string(formattedMessage))
+	// Repeats are swallowed, and a summary of them may have to be written before the entry.
+	for _, e := range l.dedup.filter(entry) {
+		l.write(e)
+	}
 }
 
-// message represents the JSON structure of the logged messages.
-// This struct is unexported (starts with a lowercase 'm') because it's only used internally by logger.go.
-type message struct {
-	Level   string `json:"level"`   // `json:"level"` is a struct tag defining how this field is named in the JSON output.
-	Message string `json:"message"` // `json:"message"` defines the JSON key for the log content.
+// write hands the entry over to the sink, which writes it wherever it wants.
+func (l *Logger) write(entry Entry) {
+	// A sink reports its own failures as best it can: there's nothing more the logger can do about them,
+	// so the error is explicitly ignored with `_ =`.
+	_ = l.sink.Write(entry)
 }
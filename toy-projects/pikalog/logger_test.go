@@ -1,8 +1,17 @@
 package pikalog_test
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"learning-go/pikalog"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 // ExampleLogger_Debugf demonstrates the usage of Debugf.
@@ -79,6 +88,778 @@ func TestLogger_DebugInfoError(t *testing.T) {
 	}
 }
 
+// requestIDKey is the type of the context key under which tests store a request ID.
+// Using a dedicated type avoids collisions with keys defined by other packages.
+type requestIDKey string
+
+func TestLogger_Ctx(t *testing.T) {
+	ctx := context.WithValue(context.Background(), requestIDKey("request_id"), "42")
+
+	tt := map[string]struct {
+		log      func(l *pikalog.Logger, ctx context.Context)
+		expected string
+	}{
+		"debug": {
+			log:      func(l *pikalog.Logger, ctx context.Context) { l.DebugCtx(ctx, "debugging %d", 1) },
+			expected: `{"level":"[DEBUG]","message":"debugging 1","request_id":"42"}` + "\n",
+		},
+		"info": {
+			log:      func(l *pikalog.Logger, ctx context.Context) { l.InfoCtx(ctx, "informing %d", 2) },
+			expected: `{"level":"[INFO]","message":"informing 2","request_id":"42"}` + "\n",
+		},
+		"warn": {
+			log:      func(l *pikalog.Logger, ctx context.Context) { l.WarnCtx(ctx, "warning %d", 3) },
+			expected: `{"level":"[WARN]","message":"warning 3","request_id":"42"}` + "\n",
+		},
+		"error": {
+			log:      func(l *pikalog.Logger, ctx context.Context) { l.ErrorCtx(ctx, "failing %d", 4) },
+			expected: `{"level":"[ERROR]","message":"failing 4","request_id":"42"}` + "\n",
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			tw := &testWriter{}
+			testedLogger := pikalog.New(pikalog.LevelDebug, pikalog.WithOutput(tw), pikalog.WithContextKeys(requestIDKey("request_id")))
+
+			tc.log(testedLogger, ctx)
+
+			if tw.contents != tc.expected {
+				t.Errorf("invalid contents, expected %q, got %q", tc.expected, tw.contents)
+			}
+		})
+	}
+
+	t.Run("missing context value", func(t *testing.T) {
+		tw := &testWriter{}
+		testedLogger := pikalog.New(pikalog.LevelDebug, pikalog.WithOutput(tw), pikalog.WithContextKeys(requestIDKey("request_id")))
+
+		testedLogger.InfoCtx(context.Background(), infoMessage)
+
+		expected := `{"level":"[INFO]","message":"` + infoMessage + "\"}\n"
+		if tw.contents != expected {
+			t.Errorf("invalid contents, expected %q, got %q", expected, tw.contents)
+		}
+	})
+
+	t.Run("below threshold", func(t *testing.T) {
+		tw := &testWriter{}
+		testedLogger := pikalog.New(pikalog.LevelError, pikalog.WithOutput(tw), pikalog.WithContextKeys(requestIDKey("request_id")))
+
+		testedLogger.WarnCtx(ctx, "warning")
+
+		if tw.contents != "" {
+			t.Errorf("expected no contents, got %q", tw.contents)
+		}
+	})
+}
+
+func TestLogger_TimeFormat(t *testing.T) {
+	fixedClock := func() time.Time {
+		return time.Date(2025, time.June, 28, 14, 5, 9, 0, time.UTC)
+	}
+
+	tt := map[string]struct {
+		opts     []pikalog.Option
+		expected string
+	}{
+		"no timestamp by default": {
+			opts:     nil,
+			expected: `{"level":"[INFO]","message":"` + infoMessage + "\"}\n",
+		},
+		"RFC 3339": {
+			opts:     []pikalog.Option{pikalog.WithTimestamp()},
+			expected: `{"level":"[INFO]","time":"2025-06-28T14:05:09Z","message":"` + infoMessage + "\"}\n",
+		},
+		"custom layout": {
+			opts:     []pikalog.Option{pikalog.WithTimeFormat("2006-01-02 15:04:05")},
+			expected: `{"level":"[INFO]","time":"2025-06-28 14:05:09","message":"` + infoMessage + "\"}\n",
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			tw := &testWriter{}
+			opts := append([]pikalog.Option{pikalog.WithOutput(tw), pikalog.WithClock(fixedClock)}, tc.opts...)
+			testedLogger := pikalog.New(pikalog.LevelInfo, opts...)
+
+			testedLogger.Infof(infoMessage)
+
+			if tw.contents != tc.expected {
+				t.Errorf("invalid contents, expected %q, got %q", tc.expected, tw.contents)
+			}
+		})
+	}
+}
+
+func TestLogger_StableKeyOrder(t *testing.T) {
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, requestIDKey("zone"), "eu-west")
+	ctx = context.WithValue(ctx, requestIDKey("request_id"), "42")
+	ctx = context.WithValue(ctx, requestIDKey("attempt"), 3)
+	ctx = context.WithValue(ctx, requestIDKey("message"), "shadowed")
+
+	fixedClock := func() time.Time {
+		return time.Date(2025, time.June, 28, 14, 5, 9, 0, time.UTC)
+	}
+
+	// Built-in keys come first, then the extra keys sorted by name.
+	// The extra "message" key is prefixed so that it doesn't hide the built-in one.
+	expected := `{"level":"[INFO]","time":"2025-06-28T14:05:09Z","message":"` + infoMessage + `",` +
+		`"attempt":3,"field_message":"shadowed","request_id":"42","zone":"eu-west"}` + "\n"
+
+	// Map iteration order is random: logging many times makes an unstable order very likely to show up.
+	for range 50 {
+		tw := &testWriter{}
+		testedLogger := pikalog.New(pikalog.LevelInfo,
+			pikalog.WithOutput(tw),
+			pikalog.WithClock(fixedClock),
+			pikalog.WithTimestamp(),
+			pikalog.WithContextKeys(requestIDKey("zone"), requestIDKey("request_id"), requestIDKey("attempt"), requestIDKey("message")),
+		)
+
+		testedLogger.InfoCtx(ctx, infoMessage)
+
+		if tw.contents != expected {
+			t.Fatalf("invalid contents, expected %q, got %q", expected, tw.contents)
+		}
+	}
+}
+
+func TestLogger_Sink(t *testing.T) {
+	fixedTime := time.Date(2025, time.June, 28, 14, 5, 9, 0, time.UTC)
+	ctx := context.WithValue(context.Background(), requestIDKey("request_id"), "42")
+
+	rs := &recordingSink{}
+	testedLogger := pikalog.New(pikalog.LevelDebug,
+		pikalog.WithSink(rs),
+		pikalog.WithClock(func() time.Time { return fixedTime }),
+		pikalog.WithContextKeys(requestIDKey("request_id")),
+	)
+
+	testedLogger.Debugf(debugMessage)
+	testedLogger.Infof(infoMessage)
+	testedLogger.Warnf("warning %d", 1)
+	testedLogger.Errorf(errorMessage)
+	testedLogger.Logf(pikalog.LevelInfo, "dynamic %s", "level")
+	testedLogger.ErrorCtx(ctx, "failing")
+
+	expected := []pikalog.Entry{
+		{Level: pikalog.LevelDebug, Time: fixedTime, Message: debugMessage},
+		{Level: pikalog.LevelInfo, Time: fixedTime, Message: infoMessage},
+		{Level: pikalog.LevelWarn, Time: fixedTime, Message: "warning 1"},
+		{Level: pikalog.LevelError, Time: fixedTime, Message: errorMessage},
+		{Level: pikalog.LevelInfo, Time: fixedTime, Message: "dynamic level"},
+		{Level: pikalog.LevelError, Time: fixedTime, Message: "failing", Fields: map[string]any{"request_id": "42"}},
+	}
+
+	if !reflect.DeepEqual(rs.entries, expected) {
+		t.Errorf("invalid entries, expected %v, got %v", expected, rs.entries)
+	}
+}
+
+func TestLogger_OneLinePerEntry(t *testing.T) {
+	tw := &testWriter{}
+	testedLogger := pikalog.New(pikalog.LevelInfo, pikalog.WithOutput(tw))
+
+	testedLogger.Infof("first line\nsecond line\tand a tab")
+
+	// The entry must fit on a single line, and be valid JSON.
+	line, rest, _ := strings.Cut(tw.contents, "\n")
+	if rest != "" {
+		t.Fatalf("expected a single line, got %q", tw.contents)
+	}
+	expected := `{"level":"[INFO]","message":"first line\nsecond line\tand a tab"}`
+	if line != expected {
+		t.Errorf("invalid contents, expected %q, got %q", expected, line)
+	}
+
+	var decoded map[string]string
+	if err := json.Unmarshal([]byte(line), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if decoded["message"] != "first line\nsecond line\tand a tab" {
+		t.Errorf("the message didn't survive the round trip: got %q", decoded["message"])
+	}
+}
+
+func TestLogger_LevelNames(t *testing.T) {
+	names := map[pikalog.Level]string{
+		pikalog.LevelDebug: "dbg",
+		pikalog.LevelInfo:  "inf",
+		pikalog.LevelError: "err",
+		// LevelWarn keeps its default name.
+	}
+
+	tw := &testWriter{}
+	testedLogger := pikalog.New(pikalog.LevelDebug, pikalog.WithOutput(tw), pikalog.WithLevelNames(names))
+	// The logger has its own copy of the names.
+	names[pikalog.LevelError] = "changed"
+
+	testedLogger.Debugf(debugMessage)
+	testedLogger.Infof(infoMessage)
+	testedLogger.Warnf("warning")
+	testedLogger.Errorf(errorMessage)
+	testedLogger.Logf(pikalog.LevelInfo, "dynamic")
+	testedLogger.ErrorCtx(context.Background(), "failing")
+
+	expected := `{"level":"dbg","message":"` + debugMessage + `"}
+{"level":"inf","message":"` + infoMessage + `"}
+{"level":"[WARN]","message":"warning"}
+{"level":"err","message":"` + errorMessage + `"}
+{"level":"inf","message":"dynamic"}
+{"level":"err","message":"failing"}
+`
+	if tw.contents != expected {
+		t.Errorf("invalid contents, expected %q, got %q", expected, tw.contents)
+	}
+}
+
+func TestLogger_PrettyJSON(t *testing.T) {
+	tt := map[string]struct {
+		opts     []pikalog.Option
+		expected string
+	}{
+		"compact by default": {
+			expected: `{"level":"[INFO]","message":"hello","request_id":"42"}` + "\n",
+		},
+		"pretty": {
+			opts: []pikalog.Option{pikalog.WithPrettyJSON()},
+			expected: `{
+  "level": "[INFO]",
+  "message": "hello",
+  "request_id": "42"
+}
+`,
+		},
+	}
+
+	ctx := context.WithValue(context.Background(), requestIDKey("request_id"), "42")
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			tw := &testWriter{}
+			opts := append([]pikalog.Option{pikalog.WithOutput(tw), pikalog.WithContextKeys(requestIDKey("request_id"))}, tc.opts...)
+			testedLogger := pikalog.New(pikalog.LevelInfo, opts...)
+
+			testedLogger.InfoCtx(ctx, "hello")
+
+			if tw.contents != tc.expected {
+				t.Errorf("invalid contents, expected %q, got %q", tc.expected, tw.contents)
+			}
+		})
+	}
+}
+
+func TestLogger_StackTraces(t *testing.T) {
+	t.Run("enabled", func(t *testing.T) {
+		rs := &recordingSink{}
+		testedLogger := pikalog.New(pikalog.LevelDebug, pikalog.WithSink(rs), pikalog.WithStackTraces())
+
+		testedLogger.Infof(infoMessage)
+		testedLogger.Errorf(errorMessage)
+
+		if len(rs.entries) != 2 {
+			t.Fatalf("expected 2 entries, got %d", len(rs.entries))
+		}
+		if _, ok := rs.entries[0].Fields["stack"]; ok {
+			t.Errorf("expected no stack for an info message, got %v", rs.entries[0].Fields)
+		}
+
+		stack, ok := rs.entries[1].Fields["stack"].(string)
+		if !ok {
+			t.Fatalf("expected a stack for an error message, got %v", rs.entries[1].Fields)
+		}
+		// The trace starts where the message was logged, without the logger's frames.
+		firstLine, _, _ := strings.Cut(stack, "\n")
+		if !strings.HasSuffix(firstLine, "TestLogger_StackTraces.func1") {
+			t.Errorf("expected the stack to start in the test, got %q", stack)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		rs := &recordingSink{}
+		testedLogger := pikalog.New(pikalog.LevelDebug, pikalog.WithSink(rs))
+
+		testedLogger.Errorf(errorMessage)
+
+		if len(rs.entries) != 1 || rs.entries[0].Fields != nil {
+			t.Errorf("expected a single entry without fields, got %v", rs.entries)
+		}
+	})
+}
+
+func TestLogger_Dedup(t *testing.T) {
+	now := time.Date(2025, time.June, 28, 14, 5, 9, 0, time.UTC)
+	tick := func(d time.Duration) { now = now.Add(d) }
+
+	tw := &testWriter{}
+	testedLogger := pikalog.New(pikalog.LevelDebug,
+		pikalog.WithOutput(tw),
+		pikalog.WithClock(func() time.Time { return now }),
+		pikalog.WithDedup(time.Minute),
+	)
+
+	// The same error, logged rapidly, is only written once.
+	for range 4 {
+		testedLogger.Errorf("disk full")
+		tick(time.Second)
+	}
+	// A different message closes the window: the summary comes first.
+	testedLogger.Infof("cleaning up")
+	testedLogger.Errorf("disk full")
+	// Once the window is over, the summary is written along with the next occurrence.
+	testedLogger.Errorf("disk full")
+	tick(2 * time.Minute)
+	testedLogger.Errorf("disk full")
+
+	expected := `{"level":"[ERROR]","message":"disk full"}
+{"level":"[ERROR]","message":"disk full (repeated 3 times)"}
+{"level":"[INFO]","message":"cleaning up"}
+{"level":"[ERROR]","message":"disk full"}
+{"level":"[ERROR]","message":"disk full (repeated 1 times)"}
+{"level":"[ERROR]","message":"disk full"}
+`
+	if tw.contents != expected {
+		t.Errorf("invalid contents, expected %q, got %q", expected, tw.contents)
+	}
+}
+
+// TestLogger_CloseFlushesDedup checks that the summary of a pending dedup window is written when the logger is closed.
+func TestLogger_CloseFlushesDedup(t *testing.T) {
+	tw := &testWriter{}
+	testedLogger := pikalog.New(pikalog.LevelDebug,
+		pikalog.WithOutput(tw),
+		pikalog.WithDedup(time.Minute),
+	)
+
+	for range 5 {
+		testedLogger.Errorf("boom")
+	}
+	if err := testedLogger.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Closing again doesn't write the summary twice.
+	if err := testedLogger.Close(); err != nil {
+		t.Fatalf("unexpected error closing twice: %v", err)
+	}
+
+	expected := `{"level":"[ERROR]","message":"boom"}
+{"level":"[ERROR]","message":"boom (repeated 4 times)"}
+`
+	if tw.contents != expected {
+		t.Errorf("invalid contents, expected %q, got %q", expected, tw.contents)
+	}
+	// The summary isn't a message logged after Close.
+	if got := testedLogger.DroppedCount(); got != 0 {
+		t.Errorf("expected no dropped message, got %d", got)
+	}
+}
+
+// TestParseLevel checks the names ParseLevel accepts, and that it rejects the others.
+func TestParseLevel(t *testing.T) {
+	tt := map[string]struct {
+		expected pikalog.Level
+		err      error
+	}{
+		"debug":   {expected: pikalog.LevelDebug},
+		"INFO":    {expected: pikalog.LevelInfo},
+		"[WARN]":  {expected: pikalog.LevelWarn},
+		"warning": {expected: pikalog.LevelWarn},
+		" Error ": {expected: pikalog.LevelError},
+		"verbose": {err: pikalog.ErrUnknownLevel},
+		"":        {err: pikalog.ErrUnknownLevel},
+	}
+
+	for input, tc := range tt {
+		t.Run(input, func(t *testing.T) {
+			got, err := pikalog.ParseLevel(input)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected level %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+// TestLogger_WithMinLevelFromEnv checks that the threshold is read from the environment,
+// and that the one given to New is kept when the variable is unset or invalid.
+func TestLogger_WithMinLevelFromEnv(t *testing.T) {
+	const envVar = "PIKALOG_TEST_LEVEL"
+
+	tt := map[string]struct {
+		value    *string // value is the content of the variable, nil to leave it unset.
+		expected string
+	}{
+		"valid value": {
+			value:    ptr("debug"),
+			expected: `{"level":"[DEBUG]","message":"debug"}` + "\n" + `{"level":"[ERROR]","message":"error"}` + "\n",
+		},
+		"unset": {
+			expected: `{"level":"[ERROR]","message":"error"}` + "\n",
+		},
+		"invalid value": {
+			value: ptr("loud"),
+			expected: `{"level":"[WARN]","message":"ignoring PIKALOG_TEST_LEVEL: unknown log level: \"loud\""}` + "\n" +
+				`{"level":"[ERROR]","message":"error"}` + "\n",
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			if tc.value != nil {
+				t.Setenv(envVar, *tc.value)
+			}
+
+			tw := &testWriter{}
+			testedLogger := pikalog.New(pikalog.LevelError, pikalog.WithOutput(tw), pikalog.WithMinLevelFromEnv(envVar))
+
+			testedLogger.Debugf("debug")
+			testedLogger.Errorf("error")
+
+			if tw.contents != tc.expected {
+				t.Errorf("invalid contents, expected %q, got %q", tc.expected, tw.contents)
+			}
+		})
+	}
+
+	t.Run("warning doesn't count in the highest level", func(t *testing.T) {
+		t.Setenv(envVar, "loud")
+
+		testedLogger := pikalog.New(pikalog.LevelError, pikalog.WithOutput(io.Discard), pikalog.WithMinLevelFromEnv(envVar))
+
+		if got := testedLogger.HighestLevel(); got != pikalog.LevelDebug {
+			t.Errorf("expected highest level %s, got %s", pikalog.LevelDebug, got)
+		}
+		if got := testedLogger.SuggestedExitCode(); got != 0 {
+			t.Errorf("expected exit code 0, got %d", got)
+		}
+	})
+}
+
+// ptr returns a pointer to s.
+func ptr(s string) *string {
+	return &s
+}
+
+// TestRingSink checks that a RingSink only keeps the most recent entries, oldest first.
+func TestRingSink(t *testing.T) {
+	tt := map[string]struct {
+		logged   int
+		expected []string
+	}{
+		"not full":       {logged: 2, expected: []string{"message 1", "message 2"}},
+		"exactly full":   {logged: 3, expected: []string{"message 1", "message 2", "message 3"}},
+		"wrapped around": {logged: 7, expected: []string{"message 5", "message 6", "message 7"}},
+		"nothing logged": {logged: 0, expected: []string{}},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			ring := pikalog.NewRingSink(3)
+			testedLogger := pikalog.New(pikalog.LevelDebug, pikalog.WithSink(ring))
+
+			for i := 1; i <= tc.logged; i++ {
+				testedLogger.Infof("message %d", i)
+			}
+
+			got := []string{}
+			for _, entry := range ring.Entries() {
+				got = append(got, entry.Message)
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected entries %v, got %v", tc.expected, got)
+			}
+		})
+	}
+
+	t.Run("concurrent use", func(t *testing.T) {
+		ring := pikalog.NewRingSink(10)
+		testedLogger := pikalog.New(pikalog.LevelDebug, pikalog.WithSink(ring))
+
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range 100 {
+					testedLogger.Infof("message %d", i)
+					_ = ring.Entries()
+				}
+			}()
+		}
+		wg.Wait()
+
+		if got := len(ring.Entries()); got != 10 {
+			t.Errorf("expected 10 entries, got %d", got)
+		}
+	})
+}
+
+// TestLogger_Close checks that logging after Close is a safe no-op, counted as dropped.
+// Run it with `go test -race` to also check that closing while other goroutines log is safe.
+func TestLogger_Close(t *testing.T) {
+	ring := pikalog.NewRingSink(1000)
+	testedLogger := pikalog.New(pikalog.LevelInfo, pikalog.WithSink(ring))
+
+	testedLogger.Infof("before close")
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				testedLogger.Infof("racing with close")
+			}
+		}()
+	}
+	if err := testedLogger.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wg.Wait()
+
+	// Whatever was logged during the race was either written or dropped, never both.
+	written := len(ring.Entries()) - 1
+	if got := int(testedLogger.DroppedCount()) + written; got != 200 {
+		t.Errorf("expected 200 messages written or dropped during the race, got %d", got)
+	}
+
+	droppedBefore := testedLogger.DroppedCount()
+	testedLogger.Infof("after close")
+	testedLogger.Errorf("after close")
+	testedLogger.Debugf("below threshold, not counted")
+
+	if got := testedLogger.DroppedCount() - droppedBefore; got != 2 {
+		t.Errorf("expected 2 more dropped messages, got %d", got)
+	}
+	if got := len(ring.Entries()) - 1; got != written {
+		t.Errorf("expected no more entries after close, got %d more", got-written)
+	}
+
+	// Closing twice is harmless.
+	if err := testedLogger.Close(); err != nil {
+		t.Errorf("unexpected error closing twice: %v", err)
+	}
+}
+
+func TestLogger_HighestLevel(t *testing.T) {
+	tt := map[string]struct {
+		threshold        pikalog.Level
+		levels           []pikalog.Level
+		expectedHighest  pikalog.Level
+		expectedExitCode int
+	}{
+		"nothing logged": {
+			threshold:        pikalog.LevelDebug,
+			expectedHighest:  pikalog.LevelDebug,
+			expectedExitCode: 0,
+		},
+		"debug and info": {
+			threshold:        pikalog.LevelDebug,
+			levels:           []pikalog.Level{pikalog.LevelInfo, pikalog.LevelDebug},
+			expectedHighest:  pikalog.LevelInfo,
+			expectedExitCode: 0,
+		},
+		"warning": {
+			threshold:        pikalog.LevelDebug,
+			levels:           []pikalog.Level{pikalog.LevelInfo, pikalog.LevelWarn, pikalog.LevelDebug},
+			expectedHighest:  pikalog.LevelWarn,
+			expectedExitCode: 2,
+		},
+		"error then info": {
+			threshold:        pikalog.LevelDebug,
+			levels:           []pikalog.Level{pikalog.LevelWarn, pikalog.LevelError, pikalog.LevelInfo},
+			expectedHighest:  pikalog.LevelError,
+			expectedExitCode: 1,
+		},
+		"warning below threshold": {
+			threshold:        pikalog.LevelError,
+			levels:           []pikalog.Level{pikalog.LevelWarn, pikalog.LevelInfo},
+			expectedHighest:  pikalog.LevelDebug,
+			expectedExitCode: 0,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			testedLogger := pikalog.New(tc.threshold, pikalog.WithOutput(io.Discard))
+			for _, lvl := range tc.levels {
+				testedLogger.Logf(lvl, "message at %s", lvl)
+			}
+
+			if got := testedLogger.HighestLevel(); got != tc.expectedHighest {
+				t.Errorf("expected highest level %s, got %s", tc.expectedHighest, got)
+			}
+			if got := testedLogger.SuggestedExitCode(); got != tc.expectedExitCode {
+				t.Errorf("expected exit code %d, got %d", tc.expectedExitCode, got)
+			}
+		})
+	}
+
+	t.Run("concurrent logging", func(t *testing.T) {
+		testedLogger := pikalog.New(pikalog.LevelDebug, pikalog.WithOutput(io.Discard))
+
+		var wg sync.WaitGroup
+		for i := range 50 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				testedLogger.Logf(pikalog.Level(i%4), "goroutine %d", i)
+			}()
+		}
+		wg.Wait()
+
+		if got := testedLogger.HighestLevel(); got != pikalog.LevelError {
+			t.Errorf("expected highest level %s, got %s", pikalog.LevelError, got)
+		}
+	})
+}
+
+func TestPrettyPrint(t *testing.T) {
+	tw := &testWriter{}
+	testedLogger := pikalog.New(pikalog.LevelDebug,
+		pikalog.WithOutput(tw),
+		pikalog.WithTimestamp(),
+		pikalog.WithClock(func() time.Time { return time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC) }),
+		pikalog.WithContextKeys(requestIDKey("request_id"), requestIDKey("user")),
+	)
+	ctx := context.WithValue(context.Background(), requestIDKey("request_id"), "42")
+	ctx = context.WithValue(ctx, requestIDKey("user"), "Ada Lovelace")
+
+	testedLogger.Infof("server started")
+	testedLogger.ErrorCtx(ctx, "payment of %d EUR failed", 15)
+
+	// Logs are often concatenated from several sources: some lines may not come from a logger at all,
+	// or have a different shape.
+	logs := tw.contents +
+		"panic: runtime error\n" +
+		"\n" +
+		`{"level":"[WARN]","message":"disk almost full","usage":0.93,"mount":{"path":"/var"}}` + "\n" +
+		`{"message":"no level"}` + "\n" +
+		`{"level":"NOTICE","message":"custom level name","empty":""}`
+
+	out := &strings.Builder{}
+	err := pikalog.PrettyPrint(strings.NewReader(logs), out)
+
+	expected := "2024-03-15T10:00:00Z INFO server started\n" +
+		`2024-03-15T10:00:00Z ERROR payment of 15 EUR failed request_id=42 user="Ada Lovelace"` + "\n" +
+		`WARN disk almost full mount={"path":"/var"} usage=0.93` + "\n" +
+		`NOTICE custom level name empty=""` + "\n"
+	if out.String() != expected {
+		t.Errorf("invalid output, expected %q, got %q", expected, out.String())
+	}
+
+	var malformed *pikalog.MalformedLinesError
+	if !errors.As(err, &malformed) {
+		t.Fatalf("expected a MalformedLinesError, got %v", err)
+	}
+	if !reflect.DeepEqual(malformed.Lines, []int{3, 6}) || malformed.Count() != 2 {
+		t.Errorf("expected lines 3 and 6 to be skipped, got %v", malformed.Lines)
+	}
+
+	t.Run("no malformed lines", func(t *testing.T) {
+		err := pikalog.PrettyPrint(strings.NewReader(tw.contents), io.Discard)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestLogger_With(t *testing.T) {
+	errNotFound := errors.New("no such file")
+
+	tt := map[string]struct {
+		fields   []pikalog.Field
+		expected string
+	}{
+		"duration": {
+			fields:   []pikalog.Field{pikalog.Duration("elapsed", 1500*time.Millisecond)},
+			expected: `{"level":"[INFO]","message":"done","elapsed":1500}`,
+		},
+		"sub-millisecond duration": {
+			fields:   []pikalog.Field{pikalog.Duration("elapsed", 250*time.Microsecond)},
+			expected: `{"level":"[INFO]","message":"done","elapsed":0.25}`,
+		},
+		"wrapped error": {
+			fields:   []pikalog.Field{pikalog.Err(fmt.Errorf("loading config: %w", errNotFound))},
+			expected: `{"level":"[INFO]","message":"done","error":{"chain":["no such file"],"message":"loading config: no such file"}}`,
+		},
+		"joined errors": {
+			fields:   []pikalog.Field{pikalog.Err(errors.Join(errNotFound, fmt.Errorf("retrying: %w", errNotFound)))},
+			expected: `{"level":"[INFO]","message":"done","error":{"chain":["no such file","retrying: no such file","no such file"],"message":"no such file\nretrying: no such file"}}`,
+		},
+		"plain error": {
+			fields:   []pikalog.Field{pikalog.Err(errNotFound)},
+			expected: `{"level":"[INFO]","message":"done","error":{"chain":[],"message":"no such file"}}`,
+		},
+		"nil error": {
+			fields:   []pikalog.Field{pikalog.Err(nil)},
+			expected: `{"level":"[INFO]","message":"done","error":null}`,
+		},
+		"custom field": {
+			fields:   []pikalog.Field{{Key: "attempt", Value: 3}},
+			expected: `{"level":"[INFO]","message":"done","attempt":3}`,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			tw := &testWriter{}
+			testedLogger := pikalog.New(pikalog.LevelInfo, pikalog.WithOutput(tw)).With(tc.fields...)
+
+			testedLogger.Infof("done")
+
+			if tw.contents != tc.expected+"\n" {
+				t.Errorf("invalid contents, expected %q, got %q", tc.expected+"\n", tw.contents)
+			}
+		})
+	}
+
+	t.Run("derived loggers", func(t *testing.T) {
+		tw := &testWriter{}
+		parent := pikalog.New(pikalog.LevelInfo, pikalog.WithOutput(tw), pikalog.WithContextKeys(requestIDKey("request_id")))
+		child := parent.With(pikalog.Field{Key: "request_id", Value: "from With"}, pikalog.Field{Key: "service", Value: "api"})
+		grandchild := child.With(pikalog.Field{Key: "service", Value: "billing"})
+
+		parent.Infof("parent")
+		child.InfoCtx(context.WithValue(context.Background(), requestIDKey("request_id"), "from ctx"), "child")
+		grandchild.Warnf("grandchild")
+
+		expected := `{"level":"[INFO]","message":"parent"}` + "\n" +
+			`{"level":"[INFO]","message":"child","request_id":"from ctx","service":"api"}` + "\n" +
+			`{"level":"[WARN]","message":"grandchild","request_id":"from With","service":"billing"}` + "\n"
+		if tw.contents != expected {
+			t.Errorf("invalid contents, expected %q, got %q", expected, tw.contents)
+		}
+
+		// The derived loggers share the highest level and the closing of the original.
+		if got := parent.HighestLevel(); got != pikalog.LevelWarn {
+			t.Errorf("expected highest level %s, got %s", pikalog.LevelWarn, got)
+		}
+		if err := parent.Close(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		grandchild.Errorf("after close")
+		if got := parent.DroppedCount(); got != 1 {
+			t.Errorf("expected 1 dropped message, got %d", got)
+		}
+	})
+}
+
+// recordingSink is a pikalog.Sink that keeps every entry it receives.
+type recordingSink struct {
+	entries []pikalog.Entry
+}
+
+// Write implements the pikalog.Sink interface.
+func (rs *recordingSink) Write(entry pikalog.Entry) error {
+	rs.entries = append(rs.entries, entry)
+	return nil
+}
+
 // testWriter is a helper struct that implements the io.Writer interface.
 // testWriter is a struct that implements io.Writer.
 // We use it to validate that we can write to a specific output.
@@ -94,3 +875,29 @@ func (tw *testWriter) Write(p []byte) (n int, err error) {
 	tw.contents = tw.contents + string(p)
 	return len(p), nil // Return the number of bytes written and no error.
 }
+
+// BenchmarkLogger_Debugf compares a Debug message suppressed by the threshold with one that is written.
+// The suppressed call returns before fmt.Sprintf formats anything, so it should be much cheaper.
+// The arguments are variables, like in real code: unlike constants, they're boxed into the args slice
+// on every call, formatted or not, and the allocations reported for the suppressed call show it.
+func BenchmarkLogger_Debugf(b *testing.B) {
+	b.Run("suppressed", func(b *testing.B) {
+		lgr := pikalog.New(pikalog.LevelInfo, pikalog.WithOutput(io.Discard))
+		b.ReportAllocs()
+		requestID, elapsed := 0, 12*time.Millisecond
+		for b.Loop() {
+			requestID++
+			lgr.Debugf("request %d took %s", requestID, elapsed)
+		}
+	})
+
+	b.Run("emitted", func(b *testing.B) {
+		lgr := pikalog.New(pikalog.LevelDebug, pikalog.WithOutput(io.Discard))
+		b.ReportAllocs()
+		requestID, elapsed := 0, 12*time.Millisecond
+		for b.Loop() {
+			requestID++
+			lgr.Debugf("request %d took %s", requestID, elapsed)
+		}
+	})
+}
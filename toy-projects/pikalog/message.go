@@ -0,0 +1,87 @@
+package pikalog
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+)
+
+// message represents the JSON structure of the logged messages.
+// This struct is unexported (starts with a lowercase 'm') because it's only used internally by the logger.
+//
+// Its JSON keys always come in the same order: "level", "time" (only when timestamps are enabled),
+// "message", and then the extra fields, sorted by name. An extra field named like one of the
+// built-in keys is written with a "field_" prefix, so that it doesn't hide the built-in one.
+type message struct {
+	Level   string
+	Time    string // Time is left out of the JSON when it's empty, i.e. when timestamps are disabled.
+	Message string
+	Fields  map[string]any
+}
+
+// reservedKeys are the JSON keys written for every message.
+var reservedKeys = []string{"level", "time", "message"}
+
+// MarshalJSON implements the json.Marshaler interface, writing the keys in a stable, documented order.
+// The default encoding of a struct would follow the declaration order, but it can't flatten
+// the extra fields next to the built-in keys.
+func (m message) MarshalJSON() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+
+	if err := writeKeyValue(buf, "level", m.Level); err != nil {
+		return nil, err
+	}
+
+	if m.Time != "" {
+		buf.WriteByte(',')
+		if err := writeKeyValue(buf, "time", m.Time); err != nil {
+			return nil, err
+		}
+	}
+
+	buf.WriteByte(',')
+	if err := writeKeyValue(buf, "message", m.Message); err != nil {
+		return nil, err
+	}
+
+	// Go randomises the iteration order of maps: sort the keys to always write them in the same order.
+	keys := make([]string, 0, len(m.Fields))
+	for key := range m.Fields {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		name := key
+		if slices.Contains(reservedKeys, key) {
+			name = "field_" + key
+		}
+
+		buf.WriteByte(',')
+		if err := writeKeyValue(buf, name, m.Fields[key]); err != nil {
+			return nil, err
+		}
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// writeKeyValue writes a `"key":value` JSON pair to the buffer.
+func writeKeyValue(buf *bytes.Buffer, key string, value any) error {
+	encodedKey, err := json.Marshal(key)
+	if err != nil {
+		return err
+	}
+
+	encodedValue, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	buf.Write(encodedKey)
+	buf.WriteByte(':')
+	buf.Write(encodedValue)
+	return nil
+}
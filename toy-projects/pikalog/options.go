@@ -1,6 +1,12 @@
 package pikalog
 
-import "io"
+import (
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"time"
+)
 
 // Option defines a configuration function, an optional parameter to Newthat changes the behaviour of the Logger.
 type Option func(*Logger)
@@ -19,3 +25,98 @@ func WithMaxMessageLength(maxMessageLength uint) Option {
 		lgr.maxMessageLength = maxMessageLength
 	}
 }
+
+// WithContextKeys sets the keys of the context values that the ...Ctx methods add to messages,
+// for instance a request ID. Each value is logged as an extra key of the message, named after its context key.
+func WithContextKeys(keys ...any) Option {
+	return func(lgr *Logger) {
+		lgr.contextKeys = keys
+	}
+}
+
+// WithTimestamp adds the time at which each message was logged, formatted as RFC 3339.
+func WithTimestamp() Option {
+	return WithTimeFormat(time.RFC3339)
+}
+
+// WithTimeFormat adds the time at which each message was logged, formatted with the given layout
+// (see the time package for how layouts are written, e.g. "2006-01-02 15:04:05").
+// Invalid layouts can't be detected: the characters that aren't part of a layout are printed as-is.
+func WithTimeFormat(layout string) Option {
+	return func(lgr *Logger) {
+		lgr.timeFormat = layout
+	}
+}
+
+// WithClock replaces the function the logger uses to get the current time, time.Now by default.
+// It's mostly useful in tests, to get predictable timestamps.
+func WithClock(now func() time.Time) Option {
+	return func(lgr *Logger) {
+		lgr.now = now
+	}
+}
+
+// WithPrettyJSON indents each message over several lines, with two spaces, which is easier to read
+// during local development. It's off by default: compact messages, one per line, suit log processing tools better.
+func WithPrettyJSON() Option {
+	return func(lgr *Logger) {
+		lgr.prettyJSON = true
+	}
+}
+
+// WithStackTraces adds a "stack" field to error messages, holding the stack trace of the goroutine
+// that logged them, without the logger's own frames. Lower levels never get one.
+// It's off by default, as capturing the stack is costly.
+func WithStackTraces() Option {
+	return func(lgr *Logger) {
+		lgr.stackTraces = true
+	}
+}
+
+// WithLevelNames replaces the names written for some levels, e.g. "err" instead of "[ERROR]".
+// Levels missing from the map keep their default name. The map is copied: changing it afterwards has no effect.
+func WithLevelNames(names map[Level]string) Option {
+	return func(lgr *Logger) {
+		lgr.levelNames = maps.Clone(names)
+	}
+}
+
+// WithSink replaces the default JSON output with a custom Sink, e.g. to forward entries to another logging backend.
+// When a sink is set, WithOutput, WithTimeFormat, WithPrettyJSON and WithLevelNames have no effect: the sink decides how entries are written.
+func WithSink(sink Sink) Option {
+	return func(lgr *Logger) {
+		lgr.sink = sink
+	}
+}
+
+// WithDedup suppresses the messages identical to the previous one (same level and message)
+// logged within the given window after it. Once the window is over, or as soon as a different message is logged,
+// a summary such as "disk full (repeated 3 times)" is written.
+// The logger has no timer: the summary of a closed window is written along with the next message.
+// The window is measured with the logger's clock, see WithClock.
+func WithDedup(window time.Duration) Option {
+	return func(lgr *Logger) {
+		lgr.dedup = &deduper{window: window}
+	}
+}
+
+// WithMinLevelFromEnv sets the threshold from the environment variable envVar, e.g. LOG_LEVEL=debug,
+// so that the verbosity can change without changing the code. The value is read with ParseLevel.
+// When the variable isn't set, the threshold given to New is kept.
+// When its value isn't a level, the threshold given to New is kept too, and the logger writes a warning
+// about it once, when it's created, whatever the threshold.
+func WithMinLevelFromEnv(envVar string) Option {
+	return func(lgr *Logger) {
+		value, ok := os.LookupEnv(envVar)
+		if !ok {
+			return
+		}
+
+		lvl, err := ParseLevel(value)
+		if err != nil {
+			lgr.startupWarnings = append(lgr.startupWarnings, fmt.Sprintf("ignoring %s: %v", envVar, err))
+			return
+		}
+		lgr.threshold = lvl
+	}
+}
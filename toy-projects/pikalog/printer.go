@@ -0,0 +1,134 @@
+package pikalog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MalformedLinesError is returned by PrettyPrint when some lines couldn't be read as log messages.
+// The other lines are still printed: a corrupted line doesn't hide the rest of the logs.
+type MalformedLinesError struct {
+	// Lines are the numbers of the skipped lines, starting at 1, in increasing order.
+	Lines []int
+}
+
+// Error implements the error interface.
+func (e *MalformedLinesError) Error() string {
+	numbers := make([]string, len(e.Lines))
+	for i, line := range e.Lines {
+		numbers[i] = strconv.Itoa(line)
+	}
+	return "skipped malformed log lines: " + strings.Join(numbers, ", ")
+}
+
+// Count returns the number of skipped lines.
+func (e *MalformedLinesError) Count() int {
+	return len(e.Lines)
+}
+
+// PrettyPrint reads logs written by a Logger, one JSON message per line, and writes them to w
+// in a format that's easier on human eyes, one line per message:
+//
+//	2024-03-15T10:00:00Z INFO user logged in id=42 name="Ada Lovelace"
+//
+// The time is only printed if the messages have one, and the extra fields come last, sorted by name.
+// Blank lines are ignored. Lines that aren't log messages, such as logs written with WithPrettyJSON,
+// which span several lines, are skipped: PrettyPrint carries on with the next line, and returns
+// a *MalformedLinesError listing them once everything has been read.
+// Other errors, reading r or writing to w, stop PrettyPrint right away.
+func PrettyPrint(r io.Reader, w io.Writer) error {
+	reader := bufio.NewReader(r)
+	var malformed []int
+
+	for lineNumber := 1; ; lineNumber++ {
+		// Unlike bufio.Scanner, ReadBytes has no limit on the length of a line: a long message isn't an error.
+		line, readErr := reader.ReadBytes('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return readErr
+		}
+
+		if len(bytes.TrimSpace(line)) != 0 {
+			text, ok := prettyLine(line)
+			if !ok {
+				malformed = append(malformed, lineNumber)
+			} else if _, err := fmt.Fprintln(w, text); err != nil {
+				return err
+			}
+		}
+
+		if readErr != nil {
+			break
+		}
+	}
+
+	if len(malformed) != 0 {
+		return &MalformedLinesError{Lines: malformed}
+	}
+	return nil
+}
+
+// prettyLine renders a JSON log message as "time LEVEL message fields...".
+// It returns false if the line isn't a JSON object with a level and a message.
+func prettyLine(line []byte) (string, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	// Numbers are kept as they were written, instead of being turned into float64s, which could alter them.
+	decoder.UseNumber()
+
+	var values map[string]any
+	if err := decoder.Decode(&values); err != nil {
+		return "", false
+	}
+
+	level, levelOK := values["level"].(string)
+	message, messageOK := values["message"].(string)
+	if !levelOK || !messageOK {
+		return "", false
+	}
+	timestamp, _ := values["time"].(string)
+
+	parts := make([]string, 0, len(values)+1)
+	if timestamp != "" {
+		parts = append(parts, timestamp)
+	}
+	// The default names of the levels are written between brackets in the JSON, e.g. "[INFO]".
+	parts = append(parts, strings.TrimSuffix(strings.TrimPrefix(level, "["), "]"), message)
+
+	fieldNames := make([]string, 0, len(values))
+	for name := range values {
+		if !slices.Contains(reservedKeys, name) {
+			fieldNames = append(fieldNames, name)
+		}
+	}
+	slices.Sort(fieldNames)
+
+	for _, name := range fieldNames {
+		parts = append(parts, name+"="+prettyValue(values[name]))
+	}
+	return strings.Join(parts, " "), true
+}
+
+// prettyValue renders the value of a field. Strings are printed as they are, unless they need quotes
+// to be told apart from the next field: empty strings, or strings with spaces, quotes or equal signs.
+// Other values, such as numbers or objects, are printed as compact JSON.
+func prettyValue(value any) string {
+	if s, ok := value.(string); ok {
+		if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+			return strconv.Quote(s)
+		}
+		return s
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		// The value was just decoded from JSON: encoding it again can't really fail.
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
@@ -0,0 +1,44 @@
+package pikalog
+
+import "sync"
+
+// RingSink is a Sink that keeps the most recent entries in memory, e.g. to show the last log lines on an admin page.
+// It holds a fixed number of entries: once it's full, each new entry replaces the oldest one.
+// It's safe for concurrent use: entries can be read while a logger is writing.
+type RingSink struct {
+	mu      sync.Mutex
+	entries []Entry // entries is the circular buffer. Its length is the capacity of the sink.
+	next    int     // next is the index where the next entry is written, which is the oldest entry once the sink is full.
+	full    bool    // full tells whether every slot of the buffer holds an entry.
+}
+
+// NewRingSink returns a RingSink that keeps the last size entries. A size below 1 is treated as 1.
+func NewRingSink(size int) *RingSink {
+	return &RingSink{entries: make([]Entry, max(size, 1))}
+}
+
+// Write implements the Sink interface for RingSink. It never fails.
+func (s *RingSink) Write(entry Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[s.next] = entry
+	// Wrap around to the start of the buffer after the last slot.
+	s.next = (s.next + 1) % len(s.entries)
+	if s.next == 0 {
+		s.full = true
+	}
+	return nil
+}
+
+// Entries returns a copy of the entries kept by the sink, oldest first.
+func (s *RingSink) Entries() []Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.full {
+		return append([]Entry(nil), s.entries[:s.next]...)
+	}
+	// The oldest entries are after the next slot to write, the newest ones before it.
+	return append(append([]Entry(nil), s.entries[s.next:]...), s.entries[:s.next]...)
+}
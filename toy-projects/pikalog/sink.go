@@ -0,0 +1,84 @@
+package pikalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Entry is a log message, as handed over by the Logger to its Sink.
+type Entry struct {
+	Level   Level          // Level is the severity of the message.
+	Time    time.Time      // Time is when the message was logged.
+	Message string         // Message is the formatted (and possibly trimmed) message.
+	Fields  map[string]any // Fields are the extra structured values of the message. It can be nil.
+}
+
+// Sink receives the entries of a Logger, and writes them wherever it wants:
+// a file, a logging backend, memory for tests...
+// Implementations are called once per entry that passes the logger's threshold.
+type Sink interface {
+	Write(entry Entry) error
+}
+
+// jsonSink is the default Sink: it writes each entry as a line of JSON to an io.Writer.
+// Each entry takes exactly one line, unless pretty is set: JSON escapes the newlines (and other control characters)
+// of strings, so those of the message and fields can't break the line-delimited output.
+type jsonSink struct {
+	output     io.Writer        // output is where the JSON lines are written (e.g., console, file).
+	timeFormat string           // timeFormat is the layout of the "time" key. Empty means no timestamp.
+	pretty     bool             // pretty tells whether each entry is indented over several lines, instead of a single line.
+	levelNames map[Level]string // levelNames overrides the names of some levels in the "level" key. It can be nil.
+}
+
+// Write implements the Sink interface for jsonSink.
+func (s *jsonSink) Write(entry Entry) error {
+	msg := message{
+		Level:   s.levelName(entry.Level),
+		Message: entry.Message,
+		Fields:  entry.Fields,
+	}
+
+	// The layout is applied as-is: time.Format doesn't report invalid layouts,
+	// it prints unknown characters verbatim.
+	if s.timeFormat != "" {
+		msg.Time = entry.Time.Format(s.timeFormat)
+	}
+
+	// Encode the structured message (level + content) into JSON format.
+	// JSON is a common choice for structured logging as it's machine-readable
+	// and widely supported.
+	formattedMessage, err := s.marshal(msg)
+	if err != nil {
+		// If JSON marshaling fails (which is rare for simple structs but possible),
+		// we fall back to printing a plain error message to the sink's output.
+		// This ensures that the logging attempt itself doesn't crash the application.
+		// The `_, _ = ...` is used to explicitly ignore the return values (bytes written, error)
+		// from Fprintf, as handling an error while handling another error can get complex.
+		// The message is quoted, so that newlines it may contain don't break the one-line-per-entry output.
+		_, _ = fmt.Fprintf(s.output, "unable to format message for %q\n", entry.Message)
+		return err
+	}
+
+	// Write the JSON-formatted log message to the configured output (e.g., console).
+	// Fprintln adds a newline character at the end, which is typical for log entries.
+	_, err = fmt.Fprintln(s.output, string(formattedMessage))
+	return err
+}
+
+// marshal encodes the message as compact JSON, or as JSON indented with two spaces if the sink is pretty.
+func (s *jsonSink) marshal(msg message) ([]byte, error) {
+	if s.pretty {
+		return json.MarshalIndent(msg, "", "  ")
+	}
+	return json.Marshal(msg)
+}
+
+// levelName returns the name written for a level: the custom one if there's one, the level's String otherwise.
+func (s *jsonSink) levelName(lvl Level) string {
+	if name, ok := s.levelNames[lvl]; ok {
+		return name
+	}
+	return lvl.String()
+}
@@ -0,0 +1,36 @@
+package pikalog
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// maxStackFrames is the maximum number of frames kept in a stack trace.
+const maxStackFrames = 32
+
+// packagePrefix is how the names of this package's functions start, e.g. "learning-go/pikalog.".
+var packagePrefix = reflect.TypeOf(Logger{}).PkgPath() + "."
+
+// stackTrace returns the stack of the calling goroutine, formatted like runtime.Stack's output:
+// the function of each frame, then its file and line, indented.
+// The frames of the logger itself are trimmed, so that the trace starts where the message was logged.
+func stackTrace() string {
+	pcs := make([]uintptr, maxStackFrames)
+	// Skip runtime.Callers and stackTrace itself.
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var trace strings.Builder
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePrefix) {
+			_, _ = fmt.Fprintf(&trace, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		}
+		if !more {
+			break
+		}
+	}
+	return trace.String()
+}
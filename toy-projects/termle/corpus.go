@@ -5,6 +5,7 @@ import (
 	"math/rand"
 	"os"
 	"strings"
+	"unicode/utf8"
 )
 
 // ErrCorpusIsEmpty is a specific error returned when the word list (corpus) is empty.
@@ -12,6 +13,9 @@ import (
 // using `errors.Is(err, termle.ErrCorpusIsEmpty)`.
 const ErrCorpusIsEmpty = corpusError("corpus is empty")
 
+// ErrInconsistentLengths is returned when the words of the corpus don't all have the same number of characters.
+const ErrInconsistentLengths = corpusError("corpus words don't all have the same length")
+
 // ReadCorpus reads a list of words from a file at the given path.
 // It expects the file to contain words separated by whitespace.
 func ReadCorpus(path string) ([]string, error) {
@@ -34,11 +38,37 @@ func ReadCorpus(path string) ([]string, error) {
 	return words, nil
 }
 
-// pickWord selects a random word from the provided corpus (slice of strings).
-func pickWord(corpus []string) string {
-	// rand.Intn returns a random integer in [0, n) where n is the length of the corpus.
-	index := rand.Intn(len(corpus))
-	// Note: For truly random words across different program runs, you'd typically seed
-	// the random number generator, e.g., rand.Seed(time.Now().UnixNano()), usually once at program startup.
-	return corpus[index]
+// validateCorpus checks that all the words of the corpus have the same number of characters as the first one.
+// Lengths are counted in runes, so that multi-byte characters count as one character.
+func validateCorpus(corpus []string) error {
+	length := utf8.RuneCountInString(corpus[0])
+	for _, word := range corpus[1:] {
+		if utf8.RuneCountInString(word) != length {
+			return fmt.Errorf("%w: %q doesn't have %d characters", ErrInconsistentLengths, word, length)
+		}
+	}
+	return nil
+}
+
+// checkCorpus makes sure the words of the corpus all have the same length, unless the game g
+// was created with AssumeUniformLength. The game's options must already be applied: that's how it knows.
+// The corpus must not be empty: an empty corpus can't even give g its solution.
+func checkCorpus(corpus []string, g *Game) error {
+	if g.assumeUniformLength {
+		return nil
+	}
+	return validateCorpus(corpus)
+}
+
+// pickWord selects a random word from the provided corpus (slice of strings), using rng.
+// If rng is nil, the global generator of the math/rand package is used.
+func pickWord(corpus []string, rng *rand.Rand) string {
+	if rng == nil {
+		// rand.Intn returns a random integer in [0, n) where n is the length of the corpus.
+		// Note: since Go 1.20, the global generator is seeded randomly when the program starts,
+		// so each run gets different words.
+		return corpus[rand.Intn(len(corpus))]
+	}
+	// A generator with a known seed always picks the same word: that's how games can be reproduced.
+	return corpus[rng.Intn(len(corpus))]
 }
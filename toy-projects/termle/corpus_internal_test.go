@@ -2,6 +2,7 @@ package termle
 
 import (
 	"errors"
+	"math/rand"
 	"testing"
 )
 
@@ -48,9 +49,90 @@ func inCorpus(corpus []string, word string) bool {
 
 func TestPickWord(t *testing.T) {
 	corpus := []string{"HELLO", "SALUT", "ПРИВЕТ", "ΧΑΙΡΕ"}
-	word := pickWord(corpus)
+	word := pickWord(corpus, nil)
 
 	if !inCorpus(corpus, word) {
 		t.Errorf("expected a word in the corpus, got %q", word)
 	}
+
+	// Generators with the same seed pick the same word.
+	seeded := pickWord(corpus, rand.New(rand.NewSource(7)))
+	if again := pickWord(corpus, rand.New(rand.NewSource(7))); again != seeded {
+		t.Errorf("expected the same seed to pick %q again, got %q", seeded, again)
+	}
+}
+
+func TestCheckCorpus(t *testing.T) {
+	uniform := []string{"HELLO", "SALUT", "ΧΑΙΡΕ"}
+	mixed := []string{"HELLO", "SALUT", "ПРИВЕТ"}
+
+	tt := map[string]struct {
+		corpus []string
+		opts   []Option
+		err    error
+	}{
+		"uniform corpus":             {corpus: uniform},
+		"uniform corpus, assumed":    {corpus: uniform, opts: []Option{AssumeUniformLength()}},
+		"mixed corpus":               {corpus: mixed, err: ErrInconsistentLengths},
+		"mixed corpus, not checked":  {corpus: mixed, opts: []Option{AssumeUniformLength()}},
+		"empty corpus":               {corpus: nil, err: ErrCorpusIsEmpty},
+		"empty corpus, still caught": {corpus: nil, opts: []Option{AssumeUniformLength()}, err: ErrCorpusIsEmpty},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			if _, err := New(nil, tc.corpus, 6, tc.opts...); !errors.Is(err, tc.err) {
+				t.Errorf("New: expected err %v, got %v", tc.err, err)
+			}
+			if _, err := NewSession(tc.corpus, 6, nil, tc.opts...); !errors.Is(err, tc.err) {
+				t.Errorf("NewSession: expected err %v, got %v", tc.err, err)
+			}
+		})
+	}
+
+	t.Run("options applied once per game", func(t *testing.T) {
+		calls := 0
+		count := func(*Game) { calls++ }
+
+		if _, err := New(nil, uniform, 6, count); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("New: expected the option to be applied once, got %d", calls)
+		}
+
+		calls = 0
+		s, err := NewSession(uniform, 6, nil, count)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for range 2 {
+			if _, err := s.NextGame(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if calls != 2 {
+			t.Errorf("NewSession: expected the option to be applied once per game, got %d for 2 games", calls)
+		}
+	})
+}
+
+// BenchmarkNew measures the cost of checking a huge corpus when a game is created.
+func BenchmarkNew(b *testing.B) {
+	corpus := make([]string, 1_000_000)
+	for i := range corpus {
+		corpus[i] = "HELLO"
+	}
+
+	b.Run("checked", func(b *testing.B) {
+		for b.Loop() {
+			_, _ = New(nil, corpus, 6)
+		}
+	})
+
+	b.Run("uniform length assumed", func(b *testing.B) {
+		for b.Loop() {
+			_, _ = New(nil, corpus, 6, AssumeUniformLength())
+		}
+	})
 }
@@ -0,0 +1,33 @@
+package termle
+
+import (
+	"bufio"
+	"math/rand"
+	"os"
+	"time"
+)
+
+// NewDaily creates the game of the day: every player gets the same solution on the same calendar date,
+// like in the daily puzzle of the original game. The player's guesses are read from the standard input.
+// The solution only depends on the corpus, in its order, and on the date: the year, month and day of date
+// in its own time zone, so that the puzzle changes at midnight where the player is.
+// The configuration functions are applied like with New.
+func NewDaily(corpus []string, maxAttempts int, date time.Time, opts ...Option) (*Game, error) {
+	if len(corpus) == 0 {
+		return nil, ErrCorpusIsEmpty
+	}
+
+	rng := rand.New(rand.NewSource(dailySeed(date)))
+	g := newGame(bufio.NewReader(os.Stdin), pickWord(corpus, rng), maxAttempts, opts...)
+	if err := checkCorpus(corpus, g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// dailySeed turns a calendar date into a seed for the random generator, e.g. 20240315 for March 15th, 2024.
+// The hours, minutes, and seconds are ignored: all the moments of a day give the same seed.
+func dailySeed(date time.Time) int64 {
+	year, month, day := date.Date()
+	return int64(year)*10000 + int64(month)*100 + int64(day)
+}
@@ -0,0 +1,44 @@
+package termle
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewDaily(t *testing.T) {
+	corpus := []string{"HELLO", "WORLD", "PIZZA", "TACOS", "SUSHI", "CURRY", "BAGEL", "DONUT", "SALSA", "PASTA"}
+
+	morning := time.Date(2024, 3, 15, 8, 30, 0, 0, time.UTC)
+	evening := time.Date(2024, 3, 15, 22, 45, 0, 0, time.UTC)
+
+	first, err := NewDaily(corpus, 6, morning)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := NewDaily(corpus, 6, evening)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(first.solution) != string(second.solution) {
+		t.Errorf("expected the same solution all day, got %q and %q", string(first.solution), string(second.solution))
+	}
+
+	// A single other day could pick the same word by chance, but not a whole week.
+	differ := false
+	for days := 1; days <= 7; days++ {
+		other, err := NewDaily(corpus, 6, morning.AddDate(0, 0, days))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(other.solution) != string(first.solution) {
+			differ = true
+		}
+	}
+	if !differ {
+		t.Errorf("expected other days to have other solutions than %q", string(first.solution))
+	}
+
+	if _, err := NewDaily(nil, 6, morning); err == nil {
+		t.Error("expected an error for an empty corpus")
+	}
+}
@@ -7,71 +7,168 @@ import (
 	"os"
 	"slices"
 	"strings"
+	"time"
 )
 
 // Game represents the state of a Termle game.
 type Game struct {
 	// reader is used to get input from the player.
 	reader *bufio.Reader
+	// output is where the game's messages are printed, the standard output by default.
+	output io.Writer
 	// solution is the secret word the player needs to guess, stored as a slice of runes.
 	// Using runes allows us to correctly handle characters from various languages.
 	solution []rune
 	// maxAttempts is the maximum number of guesses the player is allowed.
 	maxAttempts int
+	// rejectRepeats tells whether a guess that was already made in this game is refused.
+	rejectRepeats bool
+	// hints tells whether the player can type the hint command to reveal a letter.
+	hints bool
+	// placed marks the positions of the solution the player has found, or that were revealed by a hint.
+	placed []bool
+	// validator is an extra rule a guess must follow, on top of the built-in ones. It's optional.
+	validator func([]rune) error
+	// messages are the texts the game prints to the player.
+	messages Messages
+	// history holds the guesses played so far in this game, with their feedback.
+	history []playedGuess
+	// assumeUniformLength tells whether the corpus is trusted to only hold words of the same length.
+	assumeUniformLength bool
+	// quiet tells whether the welcome message is left out.
+	quiet bool
+	// now returns the current time, time.Now by default. It's used to time the game.
+	now func() time.Time
+	// startedAt and endedAt are when the last call to Play started and returned.
+	startedAt, endedAt time.Time
+	// result is the outcome of the last call to Play. Its Attempts is 0 until a game was played.
+	result Result
+	// guessDurations holds how long the player took to make each valid guess, in order.
+	guessDurations []time.Duration
+	// slowGuessLimit is how long a guess can take before the player is warned. 0 means no warning.
+	slowGuessLimit time.Duration
+}
+
+// playedGuess is a guess played in a game, with the feedback it got.
+type playedGuess struct {
+	guess    []rune
+	feedback feedback
 }
 
 // New creates and initializes a new Termle game.
 // It takes the player's input source (e.g., os.Stdin), a list of possible words (corpus),
-// and the maximum number of attempts allowed.
-func New(playerInput io.Reader, corpus []string, maxAttempts int) (*Game, error) {
-	// It's important to have words to choose from. If the corpus is empty,
-	// we can't start a game, so we return an error.
+// the maximum number of attempts allowed, and a list of configuration functions to tune it at your will.
+func New(playerInput io.Reader, corpus []string, maxAttempts int, opts ...Option) (*Game, error) {
+	// It's important to have words to choose from, and that they all have the same length.
+	// Otherwise, we can't start a game, so we return an error.
 	if len(corpus) == 0 {
 		return nil, ErrCorpusIsEmpty
 	}
 
+	g := newGame(bufio.NewReader(playerInput), pickWord(corpus, nil), maxAttempts, opts...)
+	if err := checkCorpus(corpus, g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// newGame creates a game whose solution is the given word.
+func newGame(reader *bufio.Reader, word string, maxAttempts int, opts ...Option) *Game {
+	solution := []rune(strings.ToUpper(word))
 	g := &Game{
-		reader:   bufio.NewReader(playerInput),
-		solution: []rune(strings.ToUpper(pickWord(corpus))),
+		reader:   reader,
+		output:   os.Stdout,
+		solution: solution,
 		// The game logic assumes words are of a consistent length,
 		// and comparisons are case-insensitive, so we convert the chosen word to uppercase.
 		maxAttempts: maxAttempts,
+		placed:      make([]bool, len(solution)),
+		messages:    DefaultMessages(),
+		now:         time.Now,
 	}
 
-	return g, nil
+	for _, configFunc := range opts {
+		configFunc(g)
+	}
+
+	return g
 }
 
-func (g *Game) Play() {
-	// Welcome message to the player.
-	fmt.Println("Welcome to Termle!")
+// Result is the outcome of a game.
+type Result struct {
+	// Won tells whether the player found the solution.
+	Won bool
+	// Attempts is the number of attempts used, including the ones spent on hints.
+	Attempts int
+	// Solution is the word the player had to find.
+	Solution string
+	// Feedback holds the feedback given for each guess, in order, as displayed to the player (e.g., "💚🟡◻️◻️💚").
+	Feedback []string
+}
+
+// Play runs the game until the player finds the solution or runs out of attempts,
+// printing the game's messages, and returns its outcome.
+// The game is timed, and its outcome is kept, for Score.
+func (g *Game) Play() Result {
+	g.startedAt = g.now()
+	g.result = g.play()
+	g.endedAt = g.now()
+	return g.result
+}
+
+// play runs the game for Play.
+func (g *Game) play() Result {
+	// Welcome message to the player, unless the game is embedded somewhere it would be noise.
+	if !g.quiet {
+		_, _ = fmt.Fprintln(g.output, g.render(g.messages.Welcome, 0))
+	}
+
+	result := Result{Solution: string(g.solution)}
 
 	// The game loop continues for each attempt, up to g.maxAttempts.
 	for currentAttempt := 1; currentAttempt <= g.maxAttempts; currentAttempt++ {
+		result.Attempts = currentAttempt
+
 		// ask prompts the player for their guess and returns it.
 		guess := g.ask()
+		// No guess means the player asked for a hint, which costs this attempt.
+		if guess == nil {
+			continue
+		}
 
 		// computeFeedback compares the guess against the solution
 		// and generates feedback (correct, wrong position, absent).
 		fb := computeFeedback(guess, g.solution)
 		// Display the feedback to the player (e.g., "💚🟡◻️◻️💚").
-		fmt.Println(fb.String())
+		_, _ = fmt.Fprintln(g.output, fb.String())
+		g.markPlaced(fb)
+		g.history = append(g.history, playedGuess{guess: guess, feedback: fb})
+		result.Feedback = append(result.Feedback, fb.String())
 
 		// Check if the guess matches the solution.
 		if slices.Equal(guess, g.solution) {
-			fmt.Printf("🎉 You won! You found it in %d guess(es)! The word was: %s.\n", currentAttempt, string(g.solution))
-			return // End the game since the player won.
+			_, _ = fmt.Fprintln(g.output, g.render(g.messages.Win, currentAttempt))
+			result.Won = true
+			return result // End the game since the player won.
 		}
 	}
 
 	// If the loop finishes, it means the player used all attempts without guessing the word.
-	fmt.Printf("😞 You've lost! The solution was: %s. \n", string(g.solution))
+	_, _ = fmt.Fprintln(g.output, g.render(g.messages.Lose, result.Attempts))
+	return result
 }
 
+// hintCommand is what the player types, instead of a guess, to reveal a letter of the solution.
+const hintCommand = ":hint"
+
 // ask prompts the player for a guess, reads their input, and validates it.
 // It continues to prompt until a valid guess is entered.
+// If hints are enabled and the player asks for one, it reveals a letter and returns nil.
 func (g *Game) ask() []rune {
 	// Inform the player about the expected length of the guess.
-	fmt.Printf("Enter a %d-character guess:\n", len(g.solution))
+	_, _ = fmt.Fprintln(g.output, g.render(g.messages.Prompt, len(g.history)))
+	// The guess is timed from the prompt, invalid attempts included.
+	askedAt := g.now()
 
 	// Loop indefinitely until a valid guess is received.
 	for {
@@ -81,6 +178,11 @@ func (g *Game) ask() []rune {
 			_, _ = fmt.Fprintf(os.Stderr, "Termle failed to read your guess: %s\n", err.Error())
 			continue
 		}
+		if g.hints && strings.EqualFold(string(playerInput), hintCommand) {
+			g.revealHint()
+			return nil
+		}
+
 		guess := splitToUppercaseCharacters(string(playerInput))
 		err = g.validateGuess(guess)
 		if err != nil {
@@ -89,29 +191,89 @@ func (g *Game) ask() []rune {
 				"Your attempt is invalid with Termle's solution: %s.\n",
 				err.Error())
 		} else {
-			// If the guess is valid, return it.
+			// If the guess is valid, remember how long it took, and return it.
+			// The guess itself is recorded in the history by play, along with its feedback.
+			g.recordDuration(g.now().Sub(askedAt))
 			return guess
 		}
 	}
 }
 
+// revealHint prints the leftmost letter of the solution that the player hasn't placed yet.
+// The letter then counts as placed, so that the next hint reveals another one.
+func (g *Game) revealHint() {
+	for pos, isPlaced := range g.placed {
+		if isPlaced {
+			continue
+		}
+		g.placed[pos] = true
+		_, _ = fmt.Fprintf(g.output, "💡 Letter %d is %c.\n", pos+1, g.solution[pos])
+		return
+	}
+	_, _ = fmt.Fprintln(g.output, "💡 You've already placed every letter!")
+}
+
+// markPlaced remembers the positions of the letters the player has placed correctly.
+func (g *Game) markPlaced(fb feedback) {
+	for pos, h := range fb {
+		if h == correctPosition {
+			g.placed[pos] = true
+		}
+	}
+}
+
 // errInvalidWordLength is returned when
 // the guess has the wrong number of characters.
 var errInvalidWordLength = fmt.Errorf("invalid guess, word doesn't have the ➥same number of characters as the solution")
 
+// ErrTooLong is returned when the guess has more characters than the solution.
+// It wraps errInvalidWordLength.
+var ErrTooLong = fmt.Errorf("guess is too long: %w", errInvalidWordLength)
+
+// ErrTooShort is returned when the guess has fewer characters than the solution.
+// It wraps errInvalidWordLength.
+var ErrTooShort = fmt.Errorf("guess is too short: %w", errInvalidWordLength)
+
+// errRepeatedGuess is returned when the guess was already made in this game,
+// and the game was created with WithRejectRepeats.
+var errRepeatedGuess = fmt.Errorf("invalid guess, you already tried this word")
+
 // validateGuess ensures the guess is valid enough.
 // For Termle, "valid enough" primarily means the guess has the same number of characters as the solution.
+// A validator set with WithValidator is checked last.
 func (g *Game) validateGuess(guess []rune) error {
-	if len(guess) != len(g.solution) {
-		// Return a formatted error that includes the expected and actual lengths,
-		// and wraps the specific errInvalidWordLength for easier error checking by callers.
-		return fmt.Errorf("expected %d, got %d, %w",
-			len(g.solution), len(guess), errInvalidWordLength)
+	// Return a formatted error that tells by how many characters the guess is off,
+	// and wraps ErrTooLong or ErrTooShort (and so errInvalidWordLength) for easier error checking by callers.
+	switch {
+	case len(guess) > len(g.solution):
+		return fmt.Errorf("%d character(s) too many, expected %d, %w",
+			len(guess)-len(g.solution), len(g.solution), ErrTooLong)
+	case len(guess) < len(g.solution):
+		return fmt.Errorf("%d character(s) missing, expected %d, %w",
+			len(g.solution)-len(guess), len(g.solution), ErrTooShort)
+	}
+
+	if g.rejectRepeats && g.alreadyPlayed(guess) {
+		return fmt.Errorf("%q, %w", string(guess), errRepeatedGuess)
+	}
+
+	// The custom rule only sees guesses of the right length, so it doesn't have to check it again.
+	if g.validator != nil {
+		if err := g.validator(guess); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// alreadyPlayed tells whether the guess is in the history of the game.
+func (g *Game) alreadyPlayed(guess []rune) bool {
+	return slices.ContainsFunc(g.history, func(played playedGuess) bool {
+		return slices.Equal(played.guess, guess)
+	})
+}
+
 // splitToUppercaseCharacters converts the input string to uppercase
 // and then splits it into a slice of runes. Using runes ensures that
 // multi-byte characters (like 'é' or 'こんにちは') are handled correctly as single characters.
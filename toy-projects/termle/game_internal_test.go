@@ -2,6 +2,7 @@ package termle
 
 import (
 	"errors"
+	"maps"
 	"slices"
 	"strings"
 	"testing"
@@ -69,6 +70,301 @@ func TestGameValidateGuess(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("too long or too short", func(t *testing.T) {
+		g, _ := New(nil, []string{"SLICE"}, 0)
+
+		err := g.validateGuess([]rune("POCKET"))
+		if !errors.Is(err, ErrTooLong) || errors.Is(err, ErrTooShort) {
+			t.Errorf("expected %q, got %q", ErrTooLong, err)
+		}
+
+		err = g.validateGuess([]rune("GUE"))
+		if !errors.Is(err, ErrTooShort) || errors.Is(err, ErrTooLong) {
+			t.Errorf("expected %q, got %q", ErrTooShort, err)
+		}
+		if !strings.Contains(err.Error(), "2 character(s) missing") {
+			t.Errorf("expected the error to tell how many characters are missing, got %q", err)
+		}
+	})
+}
+
+func TestGameKeyboardState(t *testing.T) {
+	tt := map[string]struct {
+		input       string
+		maxAttempts int
+		expected    map[rune]Status
+	}{
+		"no guess yet": {
+			input:       "",
+			maxAttempts: 0,
+			expected:    map[rune]Status{},
+		},
+		"after one guess": {
+			input:       "LEMON\n",
+			maxAttempts: 1,
+			expected:    map[rune]Status{'L': WrongPosition, 'E': Correct, 'M': Absent, 'O': WrongPosition, 'N': Absent},
+		},
+		"letters upgraded to correct": {
+			input:       "LEMON\nHELLO\n",
+			maxAttempts: 2,
+			expected:    map[rune]Status{'H': Correct, 'E': Correct, 'L': Correct, 'M': Absent, 'O': Correct, 'N': Absent},
+		},
+		"status never downgraded": {
+			// E was placed by the first guess: seeing it in the wrong position afterwards doesn't change that.
+			input:       "LEMON\nEHLLO\n",
+			maxAttempts: 2,
+			expected:    map[rune]Status{'H': WrongPosition, 'E': Correct, 'L': Correct, 'M': Absent, 'O': Correct, 'N': Absent},
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			g, _ := New(strings.NewReader(tc.input), []string{"hello"}, tc.maxAttempts)
+			g.output = &strings.Builder{}
+
+			g.Play()
+
+			if got := g.KeyboardState(); !maps.Equal(got, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestGameWithMessages(t *testing.T) {
+	french := Messages{
+		Welcome: "Bienvenue !",
+		Prompt:  "Mot de {length} lettres :",
+		Win:     "Gagné en {attempts} essai(s), c'était {solution} !",
+		Lose:    "Perdu ! La solution était {solution}.",
+	}
+
+	tt := map[string]struct {
+		input    string
+		messages Messages
+		expected string
+	}{
+		"win": {
+			input:    "HELPS\nHELLO\n",
+			messages: french,
+			expected: "Bienvenue !\nMot de 5 lettres :\n💚💚💚◻️◻️\nMot de 5 lettres :\n💚💚💚💚💚\nGagné en 2 essai(s), c'était HELLO !\n",
+		},
+		"loss": {
+			input:    "OLLEH\nWORLD\n",
+			messages: french,
+			expected: "Bienvenue !\nMot de 5 lettres :\n🟡🟡💚🟡🟡\nMot de 5 lettres :\n◻️🟡◻️💚◻️\nPerdu ! La solution était HELLO.\n",
+		},
+		"empty messages keep the default": {
+			input:    "OLLEH\nWORLD\n",
+			messages: Messages{Lose: "Perdu !"},
+			expected: "Welcome to Termle!\nEnter a 5-character guess:\n🟡🟡💚🟡🟡\nEnter a 5-character guess:\n◻️🟡◻️💚◻️\nPerdu !\n",
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			g, _ := New(strings.NewReader(tc.input), []string{"hello"}, 2, WithMessages(tc.messages))
+			output := &strings.Builder{}
+			g.output = output
+
+			g.Play()
+
+			if output.String() != tc.expected {
+				t.Errorf("expected output %q, got %q", tc.expected, output.String())
+			}
+		})
+	}
+}
+
+func TestGameWithQuiet(t *testing.T) {
+	tt := map[string]struct {
+		opts     []Option
+		expected string
+	}{
+		"default": {
+			expected: "Welcome to Termle!\nEnter a 5-character guess:\n💚💚💚💚💚\n🎉 You won! You found it in 1 guess(es)! The word was: HELLO.\n",
+		},
+		"quiet": {
+			opts:     []Option{WithQuiet()},
+			expected: "Enter a 5-character guess:\n💚💚💚💚💚\n🎉 You won! You found it in 1 guess(es)! The word was: HELLO.\n",
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			g, _ := New(strings.NewReader("hello\n"), []string{"hello"}, 6, tc.opts...)
+			output := &strings.Builder{}
+			g.output = output
+
+			g.Play()
+
+			if output.String() != tc.expected {
+				t.Errorf("expected output %q, got %q", tc.expected, output.String())
+			}
+		})
+	}
+}
+
+func TestGameWithValidator(t *testing.T) {
+	errNoE := errors.New("the letter E is forbidden")
+	noE := func(guess []rune) error {
+		if slices.Contains(guess, 'E') {
+			return errNoE
+		}
+		return nil
+	}
+
+	t.Run("rejected guess is asked again", func(t *testing.T) {
+		// A single attempt: if the rejected guess cost it, WORLD would never be played.
+		g, _ := New(strings.NewReader("hello\nworld\n"), []string{"SLICE"}, 1, WithValidator(noE))
+		g.output = &strings.Builder{}
+
+		g.Play()
+
+		if len(g.history) != 1 || string(g.history[0].guess) != "WORLD" {
+			t.Errorf("expected the single guess WORLD, got %v", g.history)
+		}
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		g, _ := New(nil, []string{"SLICE"}, 6, WithValidator(noE))
+
+		if err := g.validateGuess([]rune("HELLO")); !errors.Is(err, errNoE) {
+			t.Errorf("expected %q, got %q", errNoE, err)
+		}
+	})
+
+	t.Run("length is checked first", func(t *testing.T) {
+		g, _ := New(nil, []string{"SLICE"}, 6, WithValidator(noE))
+
+		if err := g.validateGuess([]rune("HEY")); !errors.Is(err, errInvalidWordLength) {
+			t.Errorf("expected %q, got %q", errInvalidWordLength, err)
+		}
+	})
+}
+
+func TestGameRejectRepeats(t *testing.T) {
+	t.Run("repeated guess is rejected", func(t *testing.T) {
+		g, _ := New(strings.NewReader("HELLO\nHELLO\nWORLD\n"), []string{"SLICE"}, 2, WithRejectRepeats())
+		g.output = &strings.Builder{}
+
+		g.Play()
+
+		// The repeated guess must not have cost an attempt.
+		if len(g.history) != 2 || string(g.history[0].guess) != "HELLO" || string(g.history[1].guess) != "WORLD" {
+			t.Errorf("expected guesses HELLO then WORLD, got %v", g.history)
+		}
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		g, _ := New(nil, []string{"SLICE"}, 6, WithRejectRepeats())
+		g.history = []playedGuess{{guess: []rune("HELLO")}}
+
+		err := g.validateGuess([]rune("HELLO"))
+		if !errors.Is(err, errRepeatedGuess) {
+			t.Errorf("expected %q, got %q", errRepeatedGuess, err)
+		}
+	})
+
+	t.Run("repeats are allowed by default", func(t *testing.T) {
+		g, _ := New(nil, []string{"SLICE"}, 6)
+		g.history = []playedGuess{{guess: []rune("HELLO")}}
+
+		if err := g.validateGuess([]rune("HELLO")); err != nil {
+			t.Errorf("expected no error, got %q", err)
+		}
+	})
+}
+
+func TestGameHint(t *testing.T) {
+	t.Run("hint reveals the leftmost unplaced letter", func(t *testing.T) {
+		g, _ := New(strings.NewReader(":hint\n:HINT\n"), []string{"HELLO"}, 6, WithHints())
+		out := &strings.Builder{}
+		g.output = out
+		// The player already placed the H.
+		g.markPlaced(computeFeedback([]rune("HXXXX"), g.solution))
+
+		if got := g.ask(); got != nil {
+			t.Errorf("expected no guess when asking for a hint, got %q", string(got))
+		}
+		if got := g.ask(); got != nil {
+			t.Errorf("expected no guess when asking for a hint, got %q", string(got))
+		}
+
+		expected := "Enter a 5-character guess:\n💡 Letter 2 is E.\n" +
+			"Enter a 5-character guess:\n💡 Letter 3 is L.\n"
+		if out.String() != expected {
+			t.Errorf("expected %q, got %q", expected, out.String())
+		}
+	})
+
+	t.Run("hint costs an attempt", func(t *testing.T) {
+		// Two attempts: the hint uses the first one, so the correct guess comes too late.
+		g, _ := New(strings.NewReader(":hint\nWRONG\nHELLO\n"), []string{"HELLO"}, 2, WithHints())
+		out := &strings.Builder{}
+		g.output = out
+
+		g.Play()
+
+		if !strings.Contains(out.String(), "You've lost!") {
+			t.Errorf("expected the game to be lost, got %q", out.String())
+		}
+		if len(g.history) != 1 {
+			t.Errorf("expected 1 guess, got %d: %v", len(g.history), g.history)
+		}
+	})
+
+	t.Run("hint command is a regular guess without WithHints", func(t *testing.T) {
+		g, _ := New(strings.NewReader(":hint\n"), []string{"HELLO"}, 6)
+		g.output = &strings.Builder{}
+
+		if got := g.ask(); string(got) != ":HINT" {
+			t.Errorf("expected the guess :HINT, got %q", string(got))
+		}
+	})
+}
+
+func TestGamePlay(t *testing.T) {
+	tt := map[string]struct {
+		input    string
+		expected Result
+	}{
+		"won": {
+			input: "HELPS\nHELLO\n",
+			expected: Result{
+				Won:      true,
+				Attempts: 2,
+				Solution: "HELLO",
+				Feedback: []string{"💚💚💚◻️◻️", "💚💚💚💚💚"},
+			},
+		},
+		"lost": {
+			input: "OLLEH\nWORLD\nHELPS\n",
+			expected: Result{
+				Won:      false,
+				Attempts: 3,
+				Solution: "HELLO",
+				Feedback: []string{"🟡🟡💚🟡🟡", "◻️🟡◻️💚◻️", "💚💚💚◻️◻️"},
+			},
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			g, _ := New(strings.NewReader(tc.input), []string{"hello"}, 3)
+			g.output = &strings.Builder{}
+
+			got := g.Play()
+
+			if got.Won != tc.expected.Won || got.Attempts != tc.expected.Attempts || got.Solution != tc.expected.Solution {
+				t.Errorf("expected %+v, got %+v", tc.expected, got)
+			}
+			if !slices.Equal(got.Feedback, tc.expected.Feedback) {
+				t.Errorf("expected feedback %v, got %v", tc.expected.Feedback, got.Feedback)
+			}
+		})
+	}
 }
 
 func TestComputeFeedback(t *testing.T) {
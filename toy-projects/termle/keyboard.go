@@ -0,0 +1,36 @@
+package termle
+
+// Status is what the player has learned about a letter so far, as shown on a Wordle keyboard.
+// Statuses are ordered by strength: Correct beats WrongPosition, which beats Absent.
+type Status hint
+
+// These constants define the statuses a letter can have on the keyboard.
+const (
+	// Absent means the letter isn't in the solution.
+	Absent = Status(absentCharacter)
+	// WrongPosition means the letter is in the solution, but hasn't been placed yet.
+	WrongPosition = Status(wrongPosition)
+	// Correct means the letter was placed at least once.
+	Correct = Status(correctPosition)
+)
+
+// String returns a visual representation of a status, the same emoji as in the feedback.
+func (s Status) String() string {
+	return hint(s).String()
+}
+
+// KeyboardState returns the strongest status learned so far for each letter the player has guessed.
+// A letter seen in the wrong position, then placed, is Correct.
+// Letters that were never guessed aren't in the map.
+func (g *Game) KeyboardState() map[rune]Status {
+	state := make(map[rune]Status)
+	for _, fb := range g.history {
+		for pos, h := range fb.feedback {
+			letter := fb.guess[pos]
+			if current, seen := state[letter]; !seen || Status(h) > current {
+				state[letter] = Status(h)
+			}
+		}
+	}
+	return state
+}
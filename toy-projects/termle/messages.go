@@ -0,0 +1,65 @@
+package termle
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Messages holds the texts the game prints, so that it can be played in other languages.
+// Templates can contain placeholders, replaced when the message is printed:
+//   - {length} is the number of characters of the solution,
+//   - {attempts} is the number of attempts used so far (in Prompt, the number of guesses made so far),
+//   - {solution} is the word to find.
+type Messages struct {
+	// Welcome is printed when the game starts.
+	Welcome string
+	// Prompt asks the player for a guess.
+	Prompt string
+	// Win is printed when the player finds the solution.
+	Win string
+	// Lose is printed when the player runs out of attempts.
+	Lose string
+	// SlowGuess is printed after a guess that took longer than the limit set with WithSlowGuessWarning.
+	SlowGuess string
+}
+
+// DefaultMessages returns the English messages the game prints unless told otherwise with WithMessages.
+func DefaultMessages() Messages {
+	return Messages{
+		Welcome:   "Welcome to Termle!",
+		Prompt:    "Enter a {length}-character guess:",
+		Win:       "🎉 You won! You found it in {attempts} guess(es)! The word was: {solution}.",
+		Lose:      "😞 You've lost! The solution was: {solution}. ",
+		SlowGuess: "⏳ Take your time... but that one took a while!",
+	}
+}
+
+// withDefaults returns the messages, with their empty fields replaced by the default ones.
+func (m Messages) withDefaults() Messages {
+	defaults := DefaultMessages()
+	if m.Welcome == "" {
+		m.Welcome = defaults.Welcome
+	}
+	if m.Prompt == "" {
+		m.Prompt = defaults.Prompt
+	}
+	if m.Win == "" {
+		m.Win = defaults.Win
+	}
+	if m.Lose == "" {
+		m.Lose = defaults.Lose
+	}
+	if m.SlowGuess == "" {
+		m.SlowGuess = defaults.SlowGuess
+	}
+	return m
+}
+
+// render replaces the placeholders of a message template with the game's values.
+func (g *Game) render(template string, attempts int) string {
+	return strings.NewReplacer(
+		"{length}", strconv.Itoa(len(g.solution)),
+		"{attempts}", strconv.Itoa(attempts),
+		"{solution}", string(g.solution),
+	).Replace(template)
+}
@@ -0,0 +1,142 @@
+package termle
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// ErrInvalidBoardCount is returned by NewMulti when the number of boards isn't positive,
+// or when the corpus doesn't have enough different words to give each board its own solution.
+var ErrInvalidBoardCount = errors.New("invalid number of boards")
+
+// ErrInvalidAttempts is returned by NewMulti when the number of attempts isn't positive:
+// the game would be over before the first guess.
+var ErrInvalidAttempts = errors.New("invalid number of attempts")
+
+// ErrGameOver is returned when a guess is made in a game that is already won or lost.
+var ErrGameOver = errors.New("the game is over")
+
+// MultiGame is a variant where each guess is played on several boards at once, each with its own solution,
+// like Dordle (2 boards) or Quordle (4 boards). The player wins when every board is solved,
+// within the attempts shared by all the boards.
+// Unlike Game, it doesn't read nor print anything: the caller sends the guesses, and displays the feedback.
+type MultiGame struct {
+	// boards hold one game per board. They provide the solutions, and check the guesses like a Game does.
+	boards []*Game
+	// solved tells, for each board, whether its solution was found.
+	solved []bool
+	// maxAttempts is the maximum number of guesses, for all the boards together.
+	maxAttempts int
+	// attempts is the number of valid guesses made so far.
+	attempts int
+}
+
+// NewMulti creates a game of numBoards boards, whose solutions are different words picked from the corpus, using rng.
+// Words are compared in uppercase, like guesses: "hello" and "HELLO" are the same word, and can't both be solutions.
+// If rng is nil, the global generator of the math/rand package is used; a seeded one always gives the same boards.
+// It returns an error if the corpus is invalid, as New does, an error wrapping ErrInvalidBoardCount
+// if numBoards isn't positive or is larger than the number of different words of the corpus,
+// and an error wrapping ErrInvalidAttempts if maxAttempts isn't positive.
+func NewMulti(corpus []string, numBoards, maxAttempts int, rng *rand.Rand) (*MultiGame, error) {
+	if len(corpus) == 0 {
+		return nil, ErrCorpusIsEmpty
+	}
+	if err := validateCorpus(corpus); err != nil {
+		return nil, err
+	}
+	words := distinctWords(corpus)
+	if numBoards < 1 || numBoards > len(words) {
+		return nil, fmt.Errorf("%w: %d, expected between 1 and %d", ErrInvalidBoardCount, numBoards, len(words))
+	}
+	if maxAttempts < 1 {
+		return nil, fmt.Errorf("%w: %d, expected at least 1", ErrInvalidAttempts, maxAttempts)
+	}
+
+	m := &MultiGame{
+		boards:      make([]*Game, numBoards),
+		solved:      make([]bool, numBoards),
+		maxAttempts: maxAttempts,
+	}
+	// Perm shuffles the positions of the words: they're all different, so taking the first ones gives distinct solutions.
+	perm := rand.Perm
+	if rng != nil {
+		perm = rng.Perm
+	}
+	for i, position := range perm(len(words))[:numBoards] {
+		m.boards[i] = newGame(nil, words[position], maxAttempts)
+	}
+	return m, nil
+}
+
+// distinctWords returns the words of the corpus in uppercase, each only once, in the order of the corpus.
+func distinctWords(corpus []string) []string {
+	seen := make(map[string]bool, len(corpus))
+	words := make([]string, 0, len(corpus))
+	for _, word := range corpus {
+		word = strings.ToUpper(word)
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+		words = append(words, word)
+	}
+	return words
+}
+
+// Guess plays a guess on every board, and returns the feedback of each board, in order.
+// A board solved by an earlier guess gets no more feedback: its entry is nil.
+// It returns an error wrapping ErrGameOver if the game is already over, or the reason why the guess is invalid,
+// e.g. ErrTooShort. An invalid guess doesn't cost an attempt.
+func (m *MultiGame) Guess(word string) ([]Feedback, error) {
+	if m.Over() {
+		return nil, fmt.Errorf("%q: %w", word, ErrGameOver)
+	}
+
+	guess := splitToUppercaseCharacters(word)
+	// All the solutions have the same length: the first board can check the guess for all of them.
+	if err := m.boards[0].validateGuess(guess); err != nil {
+		return nil, err
+	}
+	m.attempts++
+
+	feedbacks := make([]Feedback, len(m.boards))
+	for i, board := range m.boards {
+		if m.solved[i] {
+			continue
+		}
+		feedbacks[i] = ComputeFeedback(guess, board.solution)
+		m.solved[i] = solved(feedbacks[i], len(board.solution))
+	}
+	return feedbacks, nil
+}
+
+// Won tells whether every board is solved.
+func (m *MultiGame) Won() bool {
+	for _, s := range m.solved {
+		if !s {
+			return false
+		}
+	}
+	return true
+}
+
+// Over tells whether the game has ended, either won, or lost because the attempts ran out.
+func (m *MultiGame) Over() bool {
+	return m.Won() || m.attempts >= m.maxAttempts
+}
+
+// Attempts returns the number of valid guesses made so far.
+func (m *MultiGame) Attempts() int {
+	return m.attempts
+}
+
+// Solutions returns the solution of each board, in uppercase, e.g. to reveal them at the end of the game.
+func (m *MultiGame) Solutions() []string {
+	solutions := make([]string, len(m.boards))
+	for i, board := range m.boards {
+		solutions[i] = string(board.solution)
+	}
+	return solutions
+}
@@ -0,0 +1,134 @@
+package termle
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestMultiGame(t *testing.T) {
+	corpus := []string{"hello", "world"}
+
+	m, err := NewMulti(corpus, 2, 6, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	solutions := m.Solutions()
+	// With two words for two boards, both are used, in a random order.
+	if sorted := slices.Sorted(slices.Values(solutions)); !slices.Equal(sorted, []string{"HELLO", "WORLD"}) {
+		t.Fatalf("expected the solutions HELLO and WORLD, got %v", solutions)
+	}
+	first, second := solutions[0], solutions[1]
+
+	// An invalid guess is refused, without costing an attempt.
+	if _, err := m.Guess("hell"); !errors.Is(err, ErrTooShort) {
+		t.Errorf("expected %v, got %v", ErrTooShort, err)
+	}
+
+	// Each board gets its own feedback.
+	feedbacks, err := m.Guess(first)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Feedback{
+		ComputeFeedback([]rune(first), []rune(first)),
+		ComputeFeedback([]rune(first), []rune(second)),
+	}
+	if !slices.EqualFunc(feedbacks, want, slices.Equal) {
+		t.Errorf("expected feedback %v, got %v", want, feedbacks)
+	}
+	if m.Won() || m.Over() {
+		t.Error("expected the game to go on with one board solved")
+	}
+
+	// The solved board gets no more feedback.
+	feedbacks, err = m.Guess(second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if feedbacks[0] != nil || !solved(feedbacks[1], 5) {
+		t.Errorf("expected no feedback for the first board and the second one solved, got %v", feedbacks)
+	}
+	if !m.Won() || !m.Over() || m.Attempts() != 2 {
+		t.Errorf("expected a win in 2 attempts, got won %t, over %t, %d attempts", m.Won(), m.Over(), m.Attempts())
+	}
+
+	if _, err := m.Guess(first); !errors.Is(err, ErrGameOver) {
+		t.Errorf("expected %v, got %v", ErrGameOver, err)
+	}
+}
+
+func TestMultiGameLoss(t *testing.T) {
+	m, err := NewMulti([]string{"HELLO", "WORLD"}, 2, 2, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Solving a single board isn't enough.
+	for _, guess := range []string{m.Solutions()[0], "PIZZA"} {
+		if _, err := m.Guess(guess); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if m.Won() || !m.Over() {
+		t.Errorf("expected a loss, got won %t, over %t", m.Won(), m.Over())
+	}
+}
+
+func TestNewMultiInvalid(t *testing.T) {
+	tt := map[string]struct {
+		corpus      []string
+		numBoards   int
+		maxAttempts int
+		err         error
+	}{
+		"no boards":                    {corpus: []string{"HELLO", "WORLD"}, numBoards: 0, maxAttempts: 6, err: ErrInvalidBoardCount},
+		"more boards than words":       {corpus: []string{"HELLO", "WORLD"}, numBoards: 3, maxAttempts: 6, err: ErrInvalidBoardCount},
+		"duplicates aren't more words": {corpus: []string{"HELLO", "hello", "WORLD"}, numBoards: 3, maxAttempts: 6, err: ErrInvalidBoardCount},
+		"empty corpus":                 {corpus: nil, numBoards: 2, maxAttempts: 6, err: ErrCorpusIsEmpty},
+		"no attempts":                  {corpus: []string{"HELLO", "WORLD"}, numBoards: 2, maxAttempts: 0, err: ErrInvalidAttempts},
+		"negative attempts":            {corpus: []string{"HELLO", "WORLD"}, numBoards: 2, maxAttempts: -1, err: ErrInvalidAttempts},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewMulti(tc.corpus, tc.numBoards, tc.maxAttempts, nil); !errors.Is(err, tc.err) {
+				t.Errorf("expected %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestNewMultiDistinctSolutions(t *testing.T) {
+	// Half of the corpus is duplicates: picking positions at random would often give two boards the same word.
+	corpus := []string{"HELLO", "HELLO", "hello", "WORLD", "WORLD", "world"}
+
+	for seed := range int64(20) {
+		m, err := NewMulti(corpus, 2, 6, rand.New(rand.NewSource(seed)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if sorted := slices.Sorted(slices.Values(m.Solutions())); !slices.Equal(sorted, []string{"HELLO", "WORLD"}) {
+			t.Fatalf("seed %d: expected the solutions HELLO and WORLD, got %v", seed, m.Solutions())
+		}
+	}
+}
+
+func TestNewMultiSeeded(t *testing.T) {
+	corpus := []string{"HELLO", "WORLD", "SALUT", "HOLAS", "CIAOS"}
+
+	first, err := NewMulti(corpus, 3, 6, rand.New(rand.NewSource(7)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	again, err := NewMulti(corpus, 3, 6, rand.New(rand.NewSource(7)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The same seed always gives the same boards.
+	if !slices.Equal(first.Solutions(), again.Solutions()) {
+		t.Errorf("expected the same solutions, got %v and %v", first.Solutions(), again.Solutions())
+	}
+}
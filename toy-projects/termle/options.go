@@ -0,0 +1,75 @@
+package termle
+
+import "time"
+
+// Option defines a configuration function, an optional parameter to New that changes the behaviour of the Game.
+type Option func(*Game)
+
+// WithRejectRepeats makes the game refuse a guess that was already made in this game.
+// The player is asked again, and the refused guess doesn't cost an attempt.
+func WithRejectRepeats() Option {
+	return func(g *Game) {
+		g.rejectRepeats = true
+	}
+}
+
+// WithHints lets the player type ":hint" instead of a guess, to reveal the leftmost letter
+// of the solution they haven't placed yet. Each hint costs an attempt.
+func WithHints() Option {
+	return func(g *Game) {
+		g.hints = true
+	}
+}
+
+// WithValidator adds a custom rule that guesses must follow, such as a dictionary check or a hard-mode rule.
+// The validator receives the uppercase guess, once it passed the built-in checks.
+// A guess it rejects is refused like any invalid guess: the error is shown and the player is asked again.
+func WithValidator(validator func(guess []rune) error) Option {
+	return func(g *Game) {
+		g.validator = validator
+	}
+}
+
+// WithMessages replaces the texts the game prints, e.g. to translate them.
+// Empty fields keep their default, English, text. See Messages for the placeholders templates can use.
+func WithMessages(messages Messages) Option {
+	return func(g *Game) {
+		g.messages = messages.withDefaults()
+	}
+}
+
+// WithQuiet leaves out the welcome message, e.g. when the game is embedded in another program.
+// The prompts and the feedback are still printed: the player needs them to play.
+// To change the welcome message rather than remove it, use WithMessages.
+func WithQuiet() Option {
+	return func(g *Game) {
+		g.quiet = true
+	}
+}
+
+// WithClock replaces the function the game uses to get the current time, time.Now by default.
+// It's used to time the game for Score. It's mostly useful in tests.
+func WithClock(now func() time.Time) Option {
+	return func(g *Game) {
+		g.now = now
+	}
+}
+
+// WithSlowGuessWarning prints a gentle warning after each guess that took the player longer than limit,
+// for a timed variant of the game. It's only a warning: slow guesses aren't penalized.
+// The warning text is the SlowGuess message, see WithMessages. Time is measured with the game's clock, see WithClock.
+func WithSlowGuessWarning(limit time.Duration) Option {
+	return func(g *Game) {
+		g.slowGuessLimit = limit
+	}
+}
+
+// AssumeUniformLength skips checking that all the words of the corpus have the same length,
+// which takes a while with huge corpora: the length of the first word is trusted to be everyone's.
+// Use it only with corpora known to be valid: a word of a different length would make a game
+// where the player's guesses are compared to a solution of an unexpected length.
+func AssumeUniformLength() Option {
+	return func(g *Game) {
+		g.assumeUniformLength = true
+	}
+}
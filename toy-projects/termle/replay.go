@@ -0,0 +1,43 @@
+package termle
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrInvalidTranscript is returned by ReplayTranscript when the guesses can't be those of a finished game.
+var ErrInvalidTranscript = errors.New("invalid transcript")
+
+// ReplayTranscript replays a recorded game, e.g. from a bug report: the solution is fixed,
+// and the guesses are taken from the transcript instead of being asked to a player. Nothing is printed.
+// The guesses are checked and scored exactly as in Play, and the outcome is returned.
+// It returns an error wrapping ErrInvalidTranscript, along with the outcome of the guesses replayed so far,
+// if a guess would have been refused by the game (wrapping the reason, e.g. ErrTooLong),
+// if there are guesses after the end of the game, or if the game ends before the solution is found
+// or the attempts are spent.
+func ReplayTranscript(solution string, guesses []string, maxAttempts int) (Result, error) {
+	g := newGame(nil, solution, maxAttempts)
+	result := Result{Solution: string(g.solution)}
+
+	for i, raw := range guesses {
+		if result.Won || i >= maxAttempts {
+			return result, fmt.Errorf("%w: %d guess(es) after the end of the game", ErrInvalidTranscript, len(guesses)-i)
+		}
+
+		guess := splitToUppercaseCharacters(raw)
+		if err := g.validateGuess(guess); err != nil {
+			return result, fmt.Errorf("%w: guess %d %q: %w", ErrInvalidTranscript, i+1, raw, err)
+		}
+		fb := computeFeedback(guess, g.solution)
+		g.history = append(g.history, playedGuess{guess: guess, feedback: fb})
+		result.Attempts = i + 1
+		result.Feedback = append(result.Feedback, fb.String())
+		result.Won = slices.Equal(guess, g.solution)
+	}
+
+	if !result.Won && result.Attempts < maxAttempts {
+		return result, fmt.Errorf("%w: the game stops after %d of %d attempts", ErrInvalidTranscript, result.Attempts, maxAttempts)
+	}
+	return result, nil
+}
@@ -0,0 +1,66 @@
+package termle
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestReplayTranscript(t *testing.T) {
+	tt := map[string]struct {
+		guesses     []string
+		maxAttempts int
+		expected    Result
+		err         error
+	}{
+		"win": {
+			guesses:     []string{"helps", "HELLO"},
+			maxAttempts: 6,
+			expected:    Result{Won: true, Attempts: 2, Solution: "HELLO", Feedback: []string{"💚💚💚◻️◻️", "💚💚💚💚💚"}},
+		},
+		"loss": {
+			guesses:     []string{"OLLEH", "WORLD"},
+			maxAttempts: 2,
+			expected:    Result{Won: false, Attempts: 2, Solution: "HELLO", Feedback: []string{"🟡🟡💚🟡🟡", "◻️🟡◻️💚◻️"}},
+		},
+		"invalid length": {
+			guesses:     []string{"HELPS", "HELL", "HELLO"},
+			maxAttempts: 6,
+			expected:    Result{Won: false, Attempts: 1, Solution: "HELLO", Feedback: []string{"💚💚💚◻️◻️"}},
+			err:         ErrTooShort,
+		},
+		"guesses after a win": {
+			guesses:     []string{"HELLO", "WORLD"},
+			maxAttempts: 6,
+			expected:    Result{Won: true, Attempts: 1, Solution: "HELLO", Feedback: []string{"💚💚💚💚💚"}},
+			err:         ErrInvalidTranscript,
+		},
+		"more guesses than attempts": {
+			guesses:     []string{"WORLD", "WORLD", "HELLO"},
+			maxAttempts: 2,
+			expected:    Result{Won: false, Attempts: 2, Solution: "HELLO", Feedback: []string{"◻️🟡◻️💚◻️", "◻️🟡◻️💚◻️"}},
+			err:         ErrInvalidTranscript,
+		},
+		"unfinished game": {
+			guesses:     []string{"WORLD"},
+			maxAttempts: 6,
+			expected:    Result{Won: false, Attempts: 1, Solution: "HELLO", Feedback: []string{"◻️🟡◻️💚◻️"}},
+			err:         ErrInvalidTranscript,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			got, err := ReplayTranscript("hello", tc.guesses, tc.maxAttempts)
+			if !errors.Is(err, tc.err) {
+				t.Errorf("expected err %v, got %v", tc.err, err)
+			}
+			if tc.err != nil && !errors.Is(err, ErrInvalidTranscript) {
+				t.Errorf("expected err to wrap %v, got %v", ErrInvalidTranscript, err)
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, got)
+			}
+		})
+	}
+}
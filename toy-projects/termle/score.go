@@ -0,0 +1,33 @@
+package termle
+
+import "time"
+
+const (
+	// pointsPerSpareAttempt is what each attempt left unused when the solution is found is worth,
+	// the winning attempt included.
+	pointsPerSpareAttempt = 100
+	// speedBonusTime is how long a player has to find the solution to get a speed bonus.
+	speedBonusTime = 2 * time.Minute
+)
+
+// Score rates the last game played, for a competitive mode. Fewer attempts and a faster win score higher.
+// A lost game, or a game that wasn't played yet, scores 0. A won game scores:
+//
+//	100 × (maxAttempts − attempts + 1) + seconds left out of 2 minutes
+//
+// For example, finding the solution at the first of 6 attempts, in 30 seconds, scores 600 + 90 = 690 points.
+// Winning at the last attempt scores 100 points, plus the speed bonus.
+// Time is measured with the game's clock: see WithClock.
+func (g *Game) Score() int {
+	if !g.result.Won {
+		return 0
+	}
+
+	score := pointsPerSpareAttempt * (g.maxAttempts - g.result.Attempts + 1)
+
+	// Only whole seconds count, and a slow game gets no bonus rather than a penalty.
+	if left := speedBonusTime - g.endedAt.Sub(g.startedAt); left > 0 {
+		score += int(left / time.Second)
+	}
+	return score
+}
@@ -0,0 +1,58 @@
+package termle
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGameScore(t *testing.T) {
+	// tick returns a clock that is read as the game starts, and then shows 30 seconds later for the rest of the game:
+	// every game lasts 30 seconds.
+	tick := func() func() time.Time {
+		return scriptedClock(0, 30*time.Second)
+	}
+
+	tt := map[string]struct {
+		input    string
+		expected int
+	}{
+		// 6 attempts: 600 points for winning at the first one, 90 for the 90 seconds left.
+		"1-attempt win":       {input: "HELLO\n", expected: 690},
+		"max-attempt win":     {input: "WORLD\nWORLD\nWORLD\nWORLD\nWORLD\nHELLO\n", expected: 190},
+		"loss":                {input: "WORLD\nWORLD\nWORLD\nWORLD\nWORLD\nWORLD\n", expected: 0},
+		"game not played yet": {input: "", expected: 0},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			g, _ := New(strings.NewReader(tc.input), []string{"HELLO"}, 6, WithClock(tick()))
+			g.output = &strings.Builder{}
+
+			if tc.input != "" {
+				g.Play()
+			}
+
+			if got := g.Score(); got != tc.expected {
+				t.Errorf("expected score %d, got %d", tc.expected, got)
+			}
+		})
+	}
+
+	t.Run("slow win gets no bonus", func(t *testing.T) {
+		start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+		calls := 0
+		slowClock := func() time.Time {
+			calls++
+			return start.Add(time.Duration(calls) * time.Hour)
+		}
+		g, _ := New(strings.NewReader("HELLO\n"), []string{"HELLO"}, 6, WithClock(slowClock))
+		g.output = &strings.Builder{}
+
+		g.Play()
+
+		if got := g.Score(); got != 600 {
+			t.Errorf("expected score 600, got %d", got)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package termle
+
+import (
+	"bufio"
+	"math/rand"
+	"os"
+	"time"
+)
+
+// Session plays several games in a row, for a "practice" mode.
+// Words are drawn from the corpus in a shuffled order, and no word is repeated
+// until the whole corpus has been played. The corpus is then shuffled again.
+type Session struct {
+	// reader is shared by all the games of the session, so that no buffered input is lost between games.
+	reader *bufio.Reader
+	// words is the session's own copy of the corpus, in the order the words will be played.
+	words []string
+	// next is the position, in words, of the solution of the next game.
+	next int
+	// maxAttempts is the maximum number of guesses the player is allowed in each game.
+	maxAttempts int
+	// rng is used to shuffle the words.
+	rng *rand.Rand
+	// opts are applied to every game of the session.
+	opts []Option
+	// first is the first game of the session, created by NewSession. It's nil once NextGame returned it.
+	first *Game
+}
+
+// NewSession creates a session of games reading the player's guesses from the standard input.
+// If rng is nil, a generator seeded with the current time is used.
+// The configuration functions are applied to every game of the session.
+func NewSession(corpus []string, maxAttempts int, rng *rand.Rand, opts ...Option) (*Session, error) {
+	if len(corpus) == 0 {
+		return nil, ErrCorpusIsEmpty
+	}
+
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	s := &Session{
+		reader: bufio.NewReader(os.Stdin),
+		// Copy the corpus, as shuffling it in place would modify the caller's slice.
+		words:       append([]string(nil), corpus...),
+		maxAttempts: maxAttempts,
+		rng:         rng,
+		opts:        opts,
+	}
+	s.shuffle()
+
+	// The first game is created right away: the options are applied to it, once,
+	// and tell whether the lengths of the words must be checked.
+	s.first = s.drawGame()
+	if err := checkCorpus(corpus, s.first); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// NextGame returns a new game, whose solution hasn't been played yet in the current pass over the corpus.
+func (s *Session) NextGame() (*Game, error) {
+	if g := s.first; g != nil {
+		s.first = nil
+		return g, nil
+	}
+	return s.drawGame(), nil
+}
+
+// drawGame creates a game whose solution is the next word of the session.
+func (s *Session) drawGame() *Game {
+	// Every word has been played: start a new pass, in a new order.
+	if s.next == len(s.words) {
+		s.shuffle()
+	}
+
+	word := s.words[s.next]
+	s.next++
+
+	return newGame(s.reader, word, s.maxAttempts, s.opts...)
+}
+
+// shuffle puts the words in a new random order, and restarts from the first one.
+func (s *Session) shuffle() {
+	s.rng.Shuffle(len(s.words), func(i, j int) {
+		s.words[i], s.words[j] = s.words[j], s.words[i]
+	})
+	s.next = 0
+}
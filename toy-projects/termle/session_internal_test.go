@@ -0,0 +1,63 @@
+package termle
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// playPass plays as many games as there are words in the corpus, and returns their solutions in order.
+func playPass(t *testing.T, s *Session, size int) []string {
+	t.Helper()
+
+	solutions := make([]string, 0, size)
+	for range size {
+		g, err := s.NextGame()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		solutions = append(solutions, string(g.solution))
+	}
+	return solutions
+}
+
+func TestSessionNextGame(t *testing.T) {
+	corpus := []string{"HELLO", "SALUT", "HOLAS", "CIAOS", "HALLO"}
+
+	s, err := NewSession(corpus, 6, rand.New(rand.NewSource(42)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	orders := map[string]bool{}
+	for pass := range 10 {
+		solutions := playPass(t, s, len(corpus))
+
+		// Within a pass, every word is played exactly once.
+		sorted := slices.Sorted(slices.Values(solutions))
+		if !slices.Equal(sorted, slices.Sorted(slices.Values(corpus))) {
+			t.Fatalf("pass %d: expected each word of %v once, got %v", pass, corpus, solutions)
+		}
+
+		orders[strings.Join(solutions, " ")] = true
+	}
+
+	// After each pass, the corpus is reshuffled: the passes shouldn't all be played in the same order.
+	if len(orders) < 2 {
+		t.Errorf("expected the corpus to be reshuffled between passes, got the same order every time")
+	}
+
+	// The caller's corpus must be left untouched.
+	if !slices.Equal(corpus, []string{"HELLO", "SALUT", "HOLAS", "CIAOS", "HALLO"}) {
+		t.Errorf("corpus was modified: %v", corpus)
+	}
+}
+
+func TestNewSessionEmptyCorpus(t *testing.T) {
+	_, err := NewSession(nil, 6, nil)
+	if !errors.Is(err, ErrCorpusIsEmpty) {
+		t.Errorf("expected err %v, got %v", ErrCorpusIsEmpty, err)
+	}
+}
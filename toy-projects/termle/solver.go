@@ -0,0 +1,66 @@
+package termle
+
+import (
+	"slices"
+	"strings"
+)
+
+// Feedback is the status of each letter of a guess, in order, as shown to the player after the guess.
+// For example, guessing HELLO when the solution is HERTZ gives [Correct Correct Absent Absent Absent].
+type Feedback []Status
+
+// ComputeFeedback returns the feedback a game gives for a guess, against the given solution.
+// A letter is only marked WrongPosition as many times as it appears in the solution and isn't placed.
+// Both words are expected in uppercase, and of the same length.
+func ComputeFeedback(guess, solution []rune) Feedback {
+	fb := computeFeedback(guess, solution)
+	result := make(Feedback, len(fb))
+	for i, h := range fb {
+		result[i] = Status(h)
+	}
+	return result
+}
+
+// Solve plays a game against feedbackFor, which returns the feedback for a guess like a game would, e.g. to test
+// a game or to give the player a hint. It keeps the words of the corpus that are consistent with all the
+// feedback received so far, and guesses the first of them, until it finds the solution or runs out of attempts.
+// It returns the guesses it made, in uppercase, and whether it found the solution.
+// If the solution isn't in the corpus, Solve runs out of candidates, and gives up before its attempts are spent.
+func Solve(corpus []string, feedbackFor func(guess []rune) Feedback, maxAttempts int) ([]string, bool) {
+	candidates := make([][]rune, 0, len(corpus))
+	for _, word := range corpus {
+		candidates = append(candidates, []rune(strings.ToUpper(word)))
+	}
+
+	var guesses []string
+	for attempt := 1; attempt <= maxAttempts && len(candidates) > 0; attempt++ {
+		guess := candidates[0]
+		guesses = append(guesses, string(guess))
+
+		fb := feedbackFor(guess)
+		if solved(fb, len(guess)) {
+			return guesses, true
+		}
+
+		// The solution gives this exact feedback for the guess: any word that wouldn't can't be the solution.
+		// The guess itself is removed too, since it wasn't the solution.
+		candidates = slices.DeleteFunc(candidates[1:], func(candidate []rune) bool {
+			return !slices.Equal(ComputeFeedback(guess, candidate), fb)
+		})
+	}
+
+	return guesses, false
+}
+
+// solved tells whether the feedback of a guess of the given length means the guess is the solution.
+func solved(fb Feedback, length int) bool {
+	if len(fb) != length {
+		return false
+	}
+	for _, s := range fb {
+		if s != Correct {
+			return false
+		}
+	}
+	return true
+}
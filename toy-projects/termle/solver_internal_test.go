@@ -0,0 +1,82 @@
+package termle
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestComputeFeedbackExported(t *testing.T) {
+	got := ComputeFeedback([]rune("HELLO"), []rune("HERTZ"))
+	expected := Feedback{Correct, Correct, Absent, Absent, Absent}
+
+	if !slices.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestSolve(t *testing.T) {
+	corpus := []string{"hello", "world", "salut", "hertz", "helps", "eagle", "slice"}
+
+	// oracle returns the feedback of a game whose solution is the given word.
+	oracle := func(solution string) func([]rune) Feedback {
+		return func(guess []rune) Feedback {
+			return ComputeFeedback(guess, []rune(strings.ToUpper(solution)))
+		}
+	}
+
+	tt := map[string]struct {
+		solution    string
+		maxAttempts int
+		guesses     []string
+		won         bool
+	}{
+		"first guess": {
+			solution:    "HELLO",
+			maxAttempts: 6,
+			guesses:     []string{"HELLO"},
+			won:         true,
+		},
+		"narrowed down": {
+			// HELLO gives 💚💚◻️◻️◻️: HERTZ is the only other word starting with HE and without L nor O.
+			solution:    "HERTZ",
+			maxAttempts: 6,
+			guesses:     []string{"HELLO", "HERTZ"},
+			won:         true,
+		},
+		"out of attempts": {
+			solution:    "HERTZ",
+			maxAttempts: 1,
+			guesses:     []string{"HELLO"},
+			won:         false,
+		},
+		"solution not in corpus": {
+			// HELLO gives ◻️◻️◻️◻️◻️: every other word has an H, an E, an L or an O.
+			solution:    "ZZZZZ",
+			maxAttempts: 6,
+			guesses:     []string{"HELLO"},
+			won:         false,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			guesses, won := Solve(corpus, oracle(tc.solution), tc.maxAttempts)
+
+			if won != tc.won {
+				t.Errorf("expected won %t, got %t", tc.won, won)
+			}
+			if !slices.Equal(guesses, tc.guesses) {
+				t.Errorf("expected guesses %v, got %v", tc.guesses, guesses)
+			}
+		})
+	}
+
+	t.Run("wins every word of the corpus", func(t *testing.T) {
+		for _, solution := range corpus {
+			if guesses, won := Solve(corpus, oracle(solution), 6); !won {
+				t.Errorf("expected to find %s, got guesses %v", solution, guesses)
+			}
+		}
+	})
+}
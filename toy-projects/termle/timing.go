@@ -0,0 +1,24 @@
+package termle
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// GuessDurations returns how long the player took to make each valid guess, in order.
+// A guess is timed from the prompt until it's accepted, including the invalid attempts in between.
+// Hints aren't guesses: they aren't timed. Time is measured with the game's clock, see WithClock.
+func (g *Game) GuessDurations() []time.Duration {
+	return slices.Clone(g.guessDurations)
+}
+
+// recordDuration remembers how long a guess took, and warns the player if it was slow.
+func (g *Game) recordDuration(d time.Duration) {
+	g.guessDurations = append(g.guessDurations, d)
+
+	if g.slowGuessLimit > 0 && d > g.slowGuessLimit {
+		// The guess isn't in the history yet: it's recorded once its feedback is computed.
+		_, _ = fmt.Fprintln(g.output, g.render(g.messages.SlowGuess, len(g.history)+1))
+	}
+}
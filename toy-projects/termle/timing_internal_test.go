@@ -0,0 +1,63 @@
+package termle
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// scriptedClock returns a clock that moves forward by the given steps, one per reading.
+// Once the steps are used up, it stops moving.
+func scriptedClock(steps ...time.Duration) func() time.Time {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		if len(steps) > 0 {
+			now = now.Add(steps[0])
+			steps = steps[1:]
+		}
+		return now
+	}
+}
+
+func TestGameGuessDurations(t *testing.T) {
+	const win = "🎉 You won! You found it in 3 guess(es)! The word was: HELLO.\n"
+
+	tt := map[string]struct {
+		opts     []Option
+		expected string
+	}{
+		"no warning by default": {
+			expected: "Enter a 5-character guess:\n🟡◻️◻️◻️◻️\n" +
+				"Enter a 5-character guess:\n💚💚💚◻️◻️\n" +
+				"Enter a 5-character guess:\n💚💚💚💚💚\n" + win,
+		},
+		"warning after a slow guess": {
+			opts: []Option{WithSlowGuessWarning(30 * time.Second)},
+			expected: "Enter a 5-character guess:\n🟡◻️◻️◻️◻️\n" +
+				"Enter a 5-character guess:\n⏳ Take your time... but that one took a while!\n💚💚💚◻️◻️\n" +
+				"Enter a 5-character guess:\n💚💚💚💚💚\n" + win,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			// Play reads the clock when it starts, then each guess reads it at the prompt and when it's accepted.
+			clock := scriptedClock(0, 0, 5*time.Second, 0, 40*time.Second, 0, 12*time.Second)
+			opts := append([]Option{WithClock(clock), WithQuiet()}, tc.opts...)
+			g, _ := New(strings.NewReader("OASIS\nHELPS\nHELLO\n"), []string{"HELLO"}, 6, opts...)
+			output := &strings.Builder{}
+			g.output = output
+
+			g.Play()
+
+			expectedDurations := []time.Duration{5 * time.Second, 40 * time.Second, 12 * time.Second}
+			if got := g.GuessDurations(); !slices.Equal(got, expectedDurations) {
+				t.Errorf("expected durations %v, got %v", expectedDurations, got)
+			}
+			if output.String() != tc.expected {
+				t.Errorf("expected output %q, got %q", tc.expected, output.String())
+			}
+		})
+	}
+}